@@ -4,6 +4,7 @@ go 1.16
 
 require (
 	github.com/Nik-U/pbc v0.0.0-20181205041846-3e516ca0c5d6
+	github.com/consensys/gnark-crypto v0.5.3
 	github.com/ncw/gmp v1.0.4
 	github.com/stretchr/testify v1.7.0
 )
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/nikamn/BC-SSE/utils/commitment"
+	"github.com/nikamn/BC-SSE/utils/intrinsic"
+)
+
+const ceremonyDir = "./output/ceremony"
+
+func srsPath(i int) string {
+	return fmt.Sprintf("%s/srs%d", ceremonyDir, i)
+}
+
+func proofPath(i int) string {
+	return fmt.Sprintf("%s/proof%d", ceremonyDir, i)
+}
+
+// contributions returns the number of updates in the transcript so far
+func contributions() int {
+	i := 0
+	for {
+		if _, err := os.Stat(srsPath(i + 1)); os.IsNotExist(err) {
+			return i
+		}
+		i++
+	}
+}
+
+func check(err error) {
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println("usage: ceremony init -degree N | contribute | verify")
+		os.Exit(1)
+	}
+
+	switch os.Args[1] {
+	case "init":
+		cmd := flag.NewFlagSet("init", flag.ExitOnError)
+		degree := cmd.Int("degree", 10, "maximum degree of committed polynomials")
+		cmd.Parse(os.Args[2:])
+
+		intrinsic.CreateDirIfNotExist(ceremonyDir)
+
+		srs, err := commitment.NewInitialSRS(*degree)
+		check(err)
+		check(commitment.SaveSRS(srsPath(0), srs))
+		fmt.Println("written", srsPath(0))
+
+	case "contribute":
+		// each participant runs this in turn, on its own machine, and publishes the new files
+		k := contributions()
+		prev, err := commitment.LoadSRS(srsPath(k))
+		check(err)
+
+		next, proof, err := prev.Update(nil)
+		check(err)
+
+		check(commitment.SaveSRS(srsPath(k+1), next))
+
+		f, err := os.Create(proofPath(k + 1))
+		check(err)
+		_, err = proof.WriteTo(f)
+		f.Close()
+		check(err)
+
+		fmt.Printf("contribution %d written to %s and %s\n", k+1, srsPath(k+1), proofPath(k+1))
+
+	case "verify":
+		k := contributions()
+
+		transcript := make([]*commitment.SRS, k+1)
+		proofs := make([]*commitment.UpdateProof, k)
+		for i := 0; i <= k; i++ {
+			srs, err := commitment.LoadSRS(srsPath(i))
+			check(err)
+			transcript[i] = srs
+
+			if i > 0 {
+				f, err := os.Open(proofPath(i))
+				check(err)
+				proofs[i-1], err = commitment.ReadUpdateProof(f)
+				f.Close()
+				check(err)
+			}
+		}
+
+		if !commitment.VerifyCeremony(transcript, proofs) {
+			fmt.Println("ceremony transcript is invalid")
+			os.Exit(1)
+		}
+
+		// the owner and client load the final SRS from params
+		intrinsic.CreateDirIfNotExist("./output/params")
+		check(commitment.SaveSRS("./output/params/srs", transcript[k]))
+		fmt.Printf("%d contributions verified, final SRS written to ./output/params/srs\n", k)
+
+	default:
+		fmt.Println("unknown command", os.Args[1])
+		os.Exit(1)
+	}
+}
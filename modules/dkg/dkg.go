@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/nikamn/BC-SSE/utils/basic"
+	"github.com/nikamn/BC-SSE/utils/commitment"
+	"github.com/nikamn/BC-SSE/utils/curve"
+	"github.com/nikamn/BC-SSE/utils/dkg"
+	"github.com/nikamn/BC-SSE/utils/intrinsic"
+)
+
+// MaxNodes is maximum number of nodes
+const MaxNodes = 10
+
+func main() {
+
+	intrinsic.CreateDirIfNotExist("./output/params")
+	intrinsic.CreateDirIfNotExist("./output/secretShares")
+
+	/* User input theta */
+	var Theta int
+	fmt.Printf("Give number of nodes(theta). %d is the maximum number of nodes\n", MaxNodes)
+	fmt.Println(MaxNodes/2, "< (theta) <", MaxNodes)
+	fmt.Scanf("%d", &Theta)
+
+	basic.CreateFile("./output/params/Theta", fmt.Sprintf("%d", Theta))
+	/* User input theta taken */
+
+	// degree of polynomial = theta
+	polyOrder := Theta
+
+	// the KZG commitment to the joint polynomial, which client checks the shares against, is made on the SRS of
+	// the powers-of-tau ceremony, as by owner
+	srs, err := commitment.LoadSRS("./output/params/srs")
+	if err == nil && srs.GetDegree() < polyOrder {
+		err = fmt.Errorf("SRS degree %d is below Theta %d", srs.GetDegree(), polyOrder)
+	} else if err == nil && !srs.Verify() {
+		err = errors.New("SRS is not well formed")
+	}
+	if err != nil {
+		fmt.Println("can't use ./output/params/srs:", err)
+		fmt.Printf("run ceremony init -degree %d, ceremony contribute and ceremony verify, then copy its output/params/srs here\n", polyOrder)
+		os.Exit(1)
+	}
+
+	c := commitment.DLPolyCommit{}
+	c.SetupSRS(srs)
+	basic.CreateFile("./output/params/primeP", c.GetCurve().Order().String())
+
+	// every party deals its own polynomial, there is no owner
+	parties := make([]*dkg.Participant, MaxNodes)
+	for i := range parties {
+		// the dealt polynomials are secret, so they are sampled from crypto/rand
+		pt, err := dkg.NewParticipant(i+1, MaxNodes, polyOrder, nil)
+		if err != nil {
+			panic(err.Error())
+		}
+		parties[i] = pt
+	}
+
+	var complaints []dkg.Complaint
+	for _, dealer := range parties {
+		for _, receiver := range parties {
+			c := receiver.Receive(dealer.Index(), dealer.Commitment(), dealer.ShareFor(receiver.Index()))
+			if c != nil {
+				complaints = append(complaints, *c)
+			}
+		}
+	}
+
+	var answers []dkg.Answer
+	for _, c := range complaints {
+		answers = append(answers, parties[c.Dealer-1].Answer(c))
+	}
+
+	fmt.Printf("\n%d complaints\n", len(complaints))
+
+	for _, pt := range parties {
+		pt.HandleComplaints(complaints, answers)
+	}
+
+	qual := parties[0].QualifiedSet()
+	fmt.Println("\nqualified set", qual)
+
+	// the KZG data of the joint polynomial is the product of that of the qualified dealers
+	C, degreeProof := c.NewG1(), c.NewG1()
+	C.Set1()
+	degreeProof.Set1()
+	witnesses := make([]curve.Element, MaxNodes)
+	for j := range witnesses {
+		witnesses[j] = c.NewG1()
+		witnesses[j].Set1()
+	}
+	for _, dealer := range qual {
+		dealerC, dealerProof, dealerWitnesses, err := parties[dealer-1].CommitKZG(&c)
+		if err != nil {
+			panic(err.Error())
+		}
+		C.Mul(C, dealerC)
+		degreeProof.Mul(degreeProof, dealerProof)
+		for j := range witnesses {
+			witnesses[j].Mul(witnesses[j], dealerWitnesses[j])
+		}
+	}
+	commitmentJSON, _ := commitment.MarshalG1(C)
+	basic.CreateFile("./output/params/commitment", string(commitmentJSON))
+	degreeProofJSON, _ := commitment.MarshalG1(degreeProof)
+	basic.CreateFile("./output/params/degreeProof", string(degreeProofJSON))
+
+	for i, pt := range parties {
+		share, comm, err := pt.Finalize()
+		if err != nil {
+			panic(err.Error())
+		}
+
+		if i == 0 {
+			basic.CreateFile("./output/params/publicKey", dkg.PublicKey(comm).String())
+		}
+
+		// party i holds the joint polynomial at x = i, as with owner; the joint secret at x = 0 is no party's share
+		intrinsic.Save(fmt.Sprintf("./output/secretShares/party%d", pt.Index()), dkg.SharePoint(pt.Index(), share, witnesses[pt.Index()-1]))
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/nikamn/BC-SSE/utils/commitment"
+	"github.com/nikamn/BC-SSE/utils/intrinsic"
+)
+
+const indexDir = "./output/index"
+
+const srsPath = "./output/params/srs"
+
+// keyPath is the keyword key, secret to the owner and the clients
+const keyPath = "./output/params/keywordKey"
+
+func docPath(doc, name string) string {
+	return fmt.Sprintf("%s/%s/%s", indexDir, doc, name)
+}
+
+func check(err error) {
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// setup loads the SRS shared by the owner and the clients, that of the powers-of-tau ceremony.
+// Without it, a proof would not match the published commitment
+func setup() *commitment.DLPolyCommit {
+	srs, err := commitment.LoadSRS(srsPath)
+	if err != nil {
+		fmt.Println("can't load the SRS:", err)
+		fmt.Println("run ceremony init, ceremony contribute and ceremony verify, then copy its output/params/srs here")
+		os.Exit(1)
+	}
+
+	c := new(commitment.DLPolyCommit)
+	c.SetupSRS(srs)
+	return c
+}
+
+// loadKey reads the keyword key, which build creates
+func loadKey() commitment.KeywordKey {
+	b, err := ioutil.ReadFile(keyPath)
+	check(err)
+
+	key, err := hex.DecodeString(strings.TrimSpace(string(b)))
+	check(err)
+	return key
+}
+
+// loadIndex rebuilds the index of doc from the keywords kept by the owner
+func loadIndex(c *commitment.DLPolyCommit, key commitment.KeywordKey, doc string) *commitment.KeywordIndex {
+	b, err := ioutil.ReadFile(docPath(doc, "keywords"))
+	check(err)
+
+	idx, err := c.NewKeywordIndex(key, strings.Fields(string(b)))
+	check(err)
+	return idx
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println("usage: index build|prove|verify -doc NAME keyword...")
+		os.Exit(1)
+	}
+
+	cmd := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	doc := cmd.String("doc", "doc1", "name of the document")
+	cmd.Parse(os.Args[2:])
+	keywords := cmd.Args()
+
+	switch os.Args[1] {
+	case "build":
+		// owner: commit to the keyword set of the document and publish the commitment.
+		// The keyword key is made on the first build, and given to the clients over a secure channel
+		c := setup()
+		if _, err := os.Stat(keyPath); os.IsNotExist(err) {
+			key, err := commitment.NewKeywordKey(nil)
+			check(err)
+			check(ioutil.WriteFile(keyPath, []byte(hex.EncodeToString(key)), 0600))
+			fmt.Println("keyword key written to", keyPath)
+		}
+		idx, err := c.NewKeywordIndex(loadKey(), keywords)
+		check(err)
+
+		intrinsic.CreateDirIfNotExist(fmt.Sprintf("%s/%s", indexDir, *doc))
+		check(ioutil.WriteFile(docPath(*doc, "keywords"), []byte(strings.Join(keywords, "\n")), 0644))
+		data, err := commitment.MarshalG1(idx.Commitment)
+		check(err)
+		check(ioutil.WriteFile(docPath(*doc, "commitment"), data, 0644))
+
+		fmt.Printf("index of %s with %d keywords written to %s\n", *doc, len(keywords), docPath(*doc, "commitment"))
+
+	case "prove":
+		// owner: answer which of the queried keywords the document contains, with a multi-witness for those it
+		// contains and a non-membership proof for the others
+		c := setup()
+		idx := loadIndex(c, loadKey(), *doc)
+
+		answer, err := idx.ProveQuery(keywords)
+		check(err)
+		check(intrinsic.Save(docPath(*doc, "proof"), answer))
+
+		fmt.Println("proof written to", docPath(*doc, "proof"))
+
+	case "verify":
+		// client: check the answer against the published commitment
+		c := setup()
+		key := loadKey()
+
+		b, err := ioutil.ReadFile(docPath(*doc, "commitment"))
+		check(err)
+		C, err := c.UnmarshalG1(b)
+		check(err)
+
+		b, err = ioutil.ReadFile(docPath(*doc, "proof"))
+		check(err)
+		answer, err := c.UnmarshalQueryProof(b)
+		check(err)
+
+		if !c.VerifyQuery(C, key, keywords, answer) {
+			fmt.Println("invalid proof")
+			os.Exit(1)
+		}
+		if len(answer.Present) > 0 {
+			fmt.Printf("%s contains %v\n", *doc, answer.Present)
+		}
+		if len(answer.Absent) > 0 {
+			fmt.Printf("%s contains none of %v\n", *doc, answer.Absent)
+		}
+
+	default:
+		fmt.Println("unknown command", os.Args[1])
+		os.Exit(1)
+	}
+}
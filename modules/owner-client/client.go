@@ -6,6 +6,7 @@ import (
     "io/ioutil"	
 	
 	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/commitment"
 	"github.com/nikamn/BC-SSE/utils/polyring"
 	"github.com/nikamn/BC-SSE/utils/polypoint"
 	"github.com/nikamn/BC-SSE/utils/intrinsic"
@@ -17,7 +18,6 @@ import (
 const MaxNodes = 10
 
 func main() {
-
 	file, err := os.Open("./output/params/Theta")
 
 	if err != nil {
@@ -37,44 +37,124 @@ func main() {
     str := string(b) // convert content to a 'string'
     p.SetString(str, 10)
 
-    b, _ = ioutil.ReadFile("./output/params/poly") // just pass the file name
-    str = string(b) // convert content to a 'string'
-	poly := polyring.FromString(str)
+	// owner writes the polynomial, so that the reconstruction can be compared with it. dkg does not: nobody knows
+	// the joint polynomial, and the reconstruction is only checked against the commitment
+	field, err := polyring.GetField(p)
+	if err != nil {
+		panic(err.Error())
+	}
+	poly := polyring.NewEmpty()
+	b, err = ioutil.ReadFile("./output/params/poly")
+	hasPoly := err == nil
+	if hasPoly {
+		if err := field.Encoding(&poly).UnmarshalBinary(b); err != nil {
+			panic("can't read the polynomial: " + err.Error())
+		}
+		fmt.Println("\noriginalPoly: ", poly)
+	}
+
+	// the shares are only trusted if the committed polynomial has degree at most Theta
+	c := commitment.DLPolyCommit{}
+	if err := c.LoadSetup("./output/params/srs"); err != nil {
+		panic("can't load the setup: " + err.Error())
+	}
 
-	fmt.Println("\noriginalPoly: ", poly)
+	b, _ = ioutil.ReadFile("./output/params/commitment")
+	C, err := c.UnmarshalG1(b)
+	if err != nil {
+		panic("can't read the commitment: " + err.Error())
+	}
+
+	b, _ = ioutil.ReadFile("./output/params/degreeProof")
+	degreeProof, err := c.UnmarshalG1(b)
+	if err != nil {
+		panic("can't read the degree proof: " + err.Error())
+	}
+	if !c.VerifyDegree(C, polyOrder, degreeProof) {
+		panic("the committed polynomial has degree higher than Theta")
+	}
 
 	noOfParties := MaxNodes
 	
 	secretShares := make([]*polypoint.PolyPoint, noOfParties)
-	
-	X := make([]int32, noOfParties)
-	Y := make([]*gmp.Int, noOfParties)
 
+	// drop the shares whose witness does not verify against the commitment. The shares without a witness,
+	// such as those rebuilt by recover, are untrusted: they are only used if there are not enough verified shares,
+	// so that deleting the witness of a corrupted share never does better than a bad witness
+	var verifiedXs, verifiedYs, untrustedXs, untrustedYs []*gmp.Int
+	var verifiedParties, untrustedParties []int
 	for i := 0; i < noOfParties; i++ {
-		X[i] = int32(i)
-		Y[i] = gmp.NewInt(0)
-		intrinsic.Load(fmt.Sprintf("./output/secretShares/party%d", i+1), &Y[i])
-		secretShares[i] = polypoint.NewPoint(X[i], Y[i], nil)
+		secretShares[i] = polypoint.NewPoint(int32(i+1), gmp.NewInt(0), c.NewG1())
+		if err := intrinsic.Load(fmt.Sprintf("./output/secretShares/party%d", i+1), secretShares[i]); err != nil {
+			fmt.Println(err)
+			continue
+		}
+
+		// party i+1 holds the share at x = i+1
+		if secretShares[i].X != int32(i+1) {
+			fmt.Printf("\nshare of party %d is at the wrong point\n", i+1)
+			continue
+		}
+
+		x := gmp.NewInt(int64(i + 1))
+		if secretShares[i].PolyWit == nil {
+			fmt.Printf("\nshare of party %d has no witness, it is untrusted\n", i+1)
+			untrustedParties = append(untrustedParties, i+1)
+			untrustedXs = append(untrustedXs, x)
+			untrustedYs = append(untrustedYs, secretShares[i].Y)
+		} else if !c.VerifyEval(C, x, secretShares[i].Y, secretShares[i].PolyWit) {
+			fmt.Printf("\nshare of party %d does not match the commitment\n", i+1)
+		} else {
+			verifiedParties = append(verifiedParties, i+1)
+			verifiedXs = append(verifiedXs, x)
+			verifiedYs = append(verifiedYs, secretShares[i].Y)
+		}
 	}
 
-	var Xs, Ys []*gmp.Int
-	for j := 0; j < polyOrder+1; j++ {
-		Xs = append(Xs, gmp.NewInt(int64(X[j])))
-		Ys = append(Ys, Y[j])
+	parties := append([]int{}, verifiedParties...)
+	Xs := append([]*gmp.Int{}, verifiedXs...)
+	Ys := append([]*gmp.Int{}, verifiedYs...)
+	if len(verifiedXs) < polyOrder+1 {
+		fmt.Printf("\nonly %d verified shares, using the untrusted ones too\n", len(verifiedXs))
+		parties = append(parties, untrustedParties...)
+		Xs = append(Xs, untrustedXs...)
+		Ys = append(Ys, untrustedYs...)
 	}
 
-	fmt.Println("\nchosen portion of x array", Xs)
+	fmt.Println("\nx array", Xs)
 	fmt.Println("\ncorresponding y array", Ys)
 	
 	// reconstruct the share
-	reconstructedPoly, err := interpolation.LagrangeInterpolate(polyOrder, Xs, Ys, p)
+	reconstructedPoly, faulty, err := interpolation.RobustInterpolate(polyOrder, Xs, Ys, p)
 	if err != nil {
-		panic("can't recover the secret")
+		panic("can't recover the secret: " + err.Error())
+	}
+
+	for _, j := range faulty {
+		fmt.Printf("\nshare of party %d is corrupted\n", parties[j])
+	}
+
+	// the reconstruction is only accepted if it is the committed polynomial, which the untrusted shares can't fake
+	if !c.VerifyPoly(C, reconstructedPoly) {
+		fmt.Println("\nreconstructedPoly does not match the commitment")
+		os.Exit(1)
+	}
+	fmt.Println("\nreconstructedPoly matches the commitment")
+
+	// check the untrusted shares against the committed polynomial
+	y, expected := gmp.NewInt(0), gmp.NewInt(0)
+	for k, x := range untrustedXs {
+		reconstructedPoly.EvalMod(x, p, expected)
+		if y.Mod(untrustedYs[k], p).Cmp(expected) != 0 {
+			fmt.Printf("\nuntrusted share of party %d does not lie on the committed polynomial\n", untrustedParties[k])
+		}
 	}
 
-	res3 := reconstructedPoly.IsSame(poly)
 	fmt.Println("\nreconstructedPoly: ", reconstructedPoly)
-	fmt.Println("\nreconstructedPoly is same as original poly : ", res3, "\n")
+	if hasPoly {
+		res3 := reconstructedPoly.IsSame(poly)
+		fmt.Println("\nreconstructedPoly is same as original poly : ", res3, "\n")
+	}
 
 
 }
\ No newline at end of file
@@ -1,11 +1,15 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"math/rand"
+	"os"
 	
 	"github.com/ncw/gmp"
 	"github.com/nikamn/BC-SSE/utils/commitment"
+	"github.com/nikamn/BC-SSE/utils/conv"
+	"github.com/nikamn/BC-SSE/utils/curve"
 	"github.com/nikamn/BC-SSE/utils/polyring"
 	"github.com/nikamn/BC-SSE/utils/polypoint"
 	"github.com/nikamn/BC-SSE/utils/intrinsic"
@@ -16,7 +20,6 @@ import (
 const MaxNodes = 10
 
 func main() {
-
 	intrinsic.CreateDirIfNotExist("./output/params")
 	intrinsic.CreateDirIfNotExist("./output/secretShares")
 
@@ -32,26 +35,56 @@ func main() {
 	// degree of polynomial = theta
 	polyOrder := Theta
 	
-	// hardcoded large prime p for Polyring
-	p := new(gmp.Int)
-	p.SetString("57896044618658097711785492504343953926634992332820282019728792006155588075521", 10)
-	
-	basic.CreateFile("./output/params/primeP", p.String())
-
 	// random source seed
 	rnd := rand.New(rand.NewSource(99))
 
+	// use the SRS of the powers-of-tau ceremony, which the client loads too. The owner never makes its own:
+	// whoever knows the trapdoor can forge witnesses
+	srs, err := commitment.LoadSRS("./output/params/srs")
+	if err == nil && srs.GetDegree() < polyOrder {
+		err = fmt.Errorf("SRS degree %d is below Theta %d", srs.GetDegree(), polyOrder)
+	} else if err == nil && !srs.Verify() {
+		err = errors.New("SRS is not well formed")
+	}
+	if err != nil {
+		fmt.Println("can't use ./output/params/srs:", err)
+		fmt.Printf("run ceremony init -degree %d, ceremony contribute and ceremony verify, then copy its output/params/srs here\n", polyOrder)
+		os.Exit(1)
+	}
+
 	c := commitment.DLPolyCommit{}
-	c.SetupFix2(polyOrder, "218882428714186575617")
+	c.SetupSRS(srs)
+
+	// the polynomial is over the scalar field of the commitment curve, so that the witnesses match the shares
+	p := conv.BigInt2GmpInt(c.GetCurve().Order())
+	basic.CreateFile("./output/params/primeP", p.String())
 
 	// Sample a Poly
 	poly, _ := polyring.NewRand(polyOrder, rnd, p)
-	basic.CreateFile("./output/params/poly", poly.String())
+	// the polynomial is written in the compact binary encoding of Z_p, which the client reads back
+	field, err := polyring.GetField(p)
+	if err != nil {
+		panic(err.Error())
+	}
+	polyData, err := field.Encoding(&poly).MarshalBinary()
+	if err != nil {
+		panic(err.Error())
+	}
+	basic.CreateFile("./output/params/poly", string(polyData))
 	
 	C := c.NewG1()
 	// PolyCommit
-	c.Commit(C, poly)
-	basic.CreateFile("./output/params/commitment", poly.String())
+	c.CommitParallel(C, poly, 0)
+	commitmentJSON, _ := commitment.MarshalG1(C)
+	basic.CreateFile("./output/params/commitment", string(commitmentJSON))
+
+	// prove deg(poly) <= Theta, since the SRS may allow higher degrees
+	degreeProof := c.NewG1()
+	if err := c.CreateDegreeProof(degreeProof, poly, polyOrder); err != nil {
+		panic(err.Error())
+	}
+	degreeProofJSON, _ := commitment.MarshalG1(degreeProof)
+	basic.CreateFile("./output/params/degreeProof", string(degreeProofJSON))
 
 	// secret sharing with parties
 	fmt.Printf("\nSharing secret with %d parties\n\n", MaxNodes)
@@ -62,16 +95,30 @@ func main() {
 	
 	xs := make([]int32, noOfParties)
 	ys := make([]*gmp.Int, noOfParties)
-	
+
+	// evaluate at all the points at once, split over goroutines, each using a subproduct tree for many parties.
+	// Party i holds the share at x = i, as with dkg
+	points := make([]*gmp.Int, noOfParties)
+	for i := range points {
+		xs[i] = int32(i + 1)
+		points[i] = gmp.NewInt(int64(i + 1))
+	}
+	polyring.VecInit(ys)
+	poly.EvalModArrayParallel(points, p, ys, 0)
+
+	// the witnesses are computed on parallel.Workers goroutines, GOMAXPROCS by default
+	witnesses := make([]curve.Element, noOfParties)
+	for i := range witnesses {
+		witnesses[i] = c.NewG1()
+	}
+	if err := c.CreateWitnesses(witnesses, poly, points, 0); err != nil {
+		panic(err.Error())
+	}
+
 	for i := 0; i < noOfParties; i++ {
-		xs[i] = int32(i)
-		ys[i] = gmp.NewInt(0)
-		w := c.NewG1()
-		poly.EvalMod(gmp.NewInt(int64(xs[i])), p, ys[i])
-		c.CreateWitness(w, poly, gmp.NewInt(int64(xs[i])))
-		secretShares[i] = polypoint.NewPoint(xs[i], ys[i], w)
-		//fmt.Println("Party witness", i+1, secretShares[i].PolyWit)
-		intrinsic.Save(fmt.Sprintf("./output/secretShares/party%d", i+1), ys[i])
+		secretShares[i] = polypoint.NewPoint(xs[i], ys[i], witnesses[i])
+		// the share file carries the witness, so that client can check the share against the commitment
+		intrinsic.Save(fmt.Sprintf("./output/secretShares/party%d", i+1), secretShares[i])
 	}
 
 	fmt.Println("\n\nx value array\t", xs)
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/commitment"
+	"github.com/nikamn/BC-SSE/utils/intrinsic"
+	"github.com/nikamn/BC-SSE/utils/polypoint"
+	"github.com/nikamn/BC-SSE/utils/recovery"
+)
+
+// MaxNodes is maximum number of nodes
+const MaxNodes = 10
+
+func main() {
+
+	file, err := os.Open("./output/params/Theta")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	var Theta int
+	_, err = fmt.Fscanf(file, "%d\n", &Theta)
+
+	// degree of polynomial = theta
+	polyOrder := Theta
+
+	p := new(gmp.Int)
+	b, _ := ioutil.ReadFile("./output/params/primeP")
+	p.SetString(string(b), 10)
+
+	/* User input lost party */
+	var lost int
+	fmt.Printf("Give the party (1 to %d) which lost its share\n", MaxNodes)
+	fmt.Scanf("%d", &lost)
+	if lost < 1 || lost > MaxNodes {
+		fmt.Println("no such party", lost)
+		os.Exit(1)
+	}
+	/* User input lost party taken */
+
+	// party i holds the share at x = i
+	target := gmp.NewInt(int64(lost))
+
+	// the first polyOrder+1 healthy parties help
+	var helpers []int
+	var Xs, shares []*gmp.Int
+	for i := 1; i <= MaxNodes && len(helpers) < polyOrder+1; i++ {
+		if i == lost {
+			continue
+		}
+		point := polypoint.NewPoint(0, gmp.NewInt(0), commitment.Curve.NewG1())
+		if err := intrinsic.Load(fmt.Sprintf("./output/secretShares/party%d", i), point); err != nil {
+			fmt.Println(err)
+			continue
+		}
+		helpers = append(helpers, i)
+		Xs = append(Xs, gmp.NewInt(int64(i)))
+		shares = append(shares, point.Y)
+	}
+
+	if len(helpers) < polyOrder+1 {
+		fmt.Println("\nnot enough healthy parties, need", polyOrder+1)
+		os.Exit(1)
+	}
+
+	fmt.Println("\nhelping parties", helpers)
+
+	// each helper deals a mask vanishing at the lost party's index. The masks hide the helpers' shares, so they are
+	// sampled from crypto/rand
+	received := make([][]*gmp.Int, len(helpers))
+	for range helpers {
+		delta, err := recovery.NewMask(polyOrder, target, nil, p)
+		if err != nil {
+			panic(err.Error())
+		}
+		for i, m := range recovery.MaskShares(delta, Xs, p) {
+			received[i] = append(received[i], m)
+		}
+	}
+
+	contributions := make([]*gmp.Int, len(helpers))
+	for i := range helpers {
+		contributions[i] = gmp.NewInt(0)
+		recovery.Contribution(contributions[i], shares[i], received[i], p)
+	}
+
+	share := gmp.NewInt(0)
+	if err := recovery.Recover(share, polyOrder, Xs, contributions, target, p); err != nil {
+		panic("can't recover the share: " + err.Error())
+	}
+
+	// the recovered share has no witness, client checks it by error correction only
+	intrinsic.Save(fmt.Sprintf("./output/secretShares/party%d", lost), polypoint.NewPoint(int32(lost), share, nil))
+	fmt.Printf("\nrecovered share of party %d\n", lost)
+}
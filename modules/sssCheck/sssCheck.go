@@ -9,6 +9,7 @@ import (
 
 	"github.com/ncw/gmp"
 	"github.com/nikamn/BC-SSE/utils/commitment"
+	"github.com/nikamn/BC-SSE/utils/conv"
 	"github.com/nikamn/BC-SSE/utils/interpolation"
 	"github.com/nikamn/BC-SSE/utils/polypoint"
 	"github.com/nikamn/BC-SSE/utils/polyring"
@@ -37,29 +38,42 @@ func main() {
 	// degree of polynomial = theta
 	polyOrder := Theta
 	
-	// hardcoded large prime p for Polyring
-	p := new(gmp.Int)
-	p.SetString("57896044618658097711785492504343953926634992332820282019728792006155588075521", 10)
-	
-	basic.CreateFile("./output/params/primeP", p.String())
-
 	// random source seed
 	rnd := rand.New(rand.NewSource(99))
 
+	srs, err := commitment.NewSRS(polyOrder, nil)
+	if err != nil {
+		panic(err.Error())
+	}
+
 	c := commitment.DLPolyCommit{}
-	c.SetupFix2(polyOrder, "218882428714186575617")
+	c.SetupSRS(srs)
+
+	// the polynomial is over the scalar field of the commitment curve
+	p := conv.BigInt2GmpInt(c.GetCurve().Order())
+	basic.CreateFile("./output/params/primeP", p.String())
 	//fmt.Println("\nc: ",c)
 
 	// Sample a Poly and an x
 	poly, _ := polyring.NewRand(polyOrder, rnd, p)
-	basic.CreateFile("./output/params/poly", poly.String())
+	// the polynomial is written in the compact binary encoding of Z_p, as by the owner
+	field, err := polyring.GetField(p)
+	if err != nil {
+		panic(err.Error())
+	}
+	polyData, err := field.Encoding(&poly).MarshalBinary()
+	if err != nil {
+		panic(err.Error())
+	}
+	basic.CreateFile("./output/params/poly", string(polyData))
 	fmt.Println("\npoly :", poly)
 	
 	fmt.Println("\n\n//---------Verifying polynomial commitment and evaluation at random point on polynomial---------")
 	C := c.NewG1()
 	// Test PolyCommit
 	c.Commit(C, poly)
-	basic.CreateFile("./output/params/commitment", poly.String())
+	commitmentJSON, _ := commitment.MarshalG1(C)
+	basic.CreateFile("./output/params/commitment", string(commitmentJSON))
 	fmt.Println("\nCommit : ", C)
 
 	// verify poly
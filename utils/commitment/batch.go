@@ -0,0 +1,101 @@
+package commitment
+
+import (
+	"math/big"
+
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/curve"
+)
+
+// BatchVerifyEval checks many evaluation proofs at once: w[i] proves polynomial(x[i]) == y[i] against C[i],
+// or against C[0] for all i if a single commitment is given.
+// Each check e(C, g2) == e(w, g2^(alpha-x)) e(g, g2)^y is rewritten as e(C w^x g^-y, g2) == e(w, g2^alpha),
+// and a random linear combination of all checks costs two pairings in total.
+// If the batch fails, bad proofs are searched by bisection and their indices are returned
+func (c *DLPolyCommit) BatchVerifyEval(C []curve.Element, x []*gmp.Int, y []*gmp.Int, w []curve.Element) (bool, []int) {
+	if len(x) != len(y) || len(x) != len(w) || (len(C) != 1 && len(C) != len(x)) {
+		panic("mismatch length")
+	}
+
+	all := make([]int, len(x))
+	for i := range all {
+		all[i] = i
+	}
+
+	if c.batchCheck(C, x, y, w, all) {
+		return true, nil
+	}
+
+	return false, c.findBad(C, x, y, w, all)
+}
+
+// findBad returns the indices of the bad proofs among indices, which are known to fail as a batch
+func (c *DLPolyCommit) findBad(C []curve.Element, x []*gmp.Int, y []*gmp.Int, w []curve.Element, indices []int) []int {
+	if len(indices) == 1 {
+		return indices
+	}
+
+	var bad []int
+	for _, half := range [][]int{indices[:len(indices)/2], indices[len(indices)/2:]} {
+		if !c.batchCheck(C, x, y, w, half) {
+			bad = append(bad, c.findBad(C, x, y, w, half)...)
+		}
+	}
+
+	return bad
+}
+
+// batchCheck checks e(prod (C_i w_i^x_i g^-y_i)^r_i, g2) == e(prod w_i^r_i, g2^alpha) for random r_i
+func (c *DLPolyCommit) batchCheck(C []curve.Element, x []*gmp.Int, y []*gmp.Int, w []curve.Element, indices []int) bool {
+	lhs := c.NewG1()
+	rhs := c.NewG1()
+	tmp := c.NewG1()
+	order := c.curve.Order()
+
+	// sums of r_i, r_i * y_i
+	sumR := big.NewInt(0)
+	sumRY := big.NewInt(0)
+	exp := big.NewInt(0)
+
+	for _, i := range indices {
+		r, err := randomScalar(c.curve, nil)
+		if err != nil {
+			return false
+		}
+
+		// rhs *= w_i^r_i
+		tmp.PowBig(w[i], r)
+		rhs.Mul(rhs, tmp)
+
+		// lhs *= w_i^(r_i x_i)
+		exp.Mul(r, c.exponent(x[i]))
+		exp.Mod(exp, order)
+		tmp.PowBig(w[i], exp)
+		lhs.Mul(lhs, tmp)
+
+		if len(C) == 1 {
+			sumR.Add(sumR, r)
+		} else {
+			tmp.PowBig(C[i], r)
+			lhs.Mul(lhs, tmp)
+		}
+
+		exp.Mul(r, c.exponent(y[i]))
+		sumRY.Add(sumRY, exp)
+	}
+
+	if len(C) == 1 {
+		sumR.Mod(sumR, order)
+		tmp.PowBig(C[0], sumR)
+		lhs.Mul(lhs, tmp)
+	}
+
+	sumRY.Mod(sumRY, order)
+	tmp.PowBig(c.pk[0], sumRY)
+	lhs.Div(lhs, tmp)
+
+	e1 := c.curve.Pair(lhs, c.pk2[0])
+	e2 := c.curve.Pair(rhs, c.pk2[1])
+
+	return e1.Equals(e2)
+}
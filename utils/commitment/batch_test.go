@@ -0,0 +1,110 @@
+package commitment
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/curve"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDLPolyCommit_BatchVerifyEval(test *testing.T) {
+	c := new(DLPolyCommit)
+	const t = 5
+	const n = 12
+	rnd := rand.New(rand.NewSource(99))
+
+	c.SetupFix(t)
+
+	// one commitment per proof, and proofs against a single commitment
+	polys := make([]polyring.Polynomial, n)
+	C := make([]curve.Element, n)
+	x := make([]*gmp.Int, n)
+	y := make([]*gmp.Int, n)
+	w := make([]curve.Element, n)
+	single := make([]*gmp.Int, n)
+	singleW := make([]curve.Element, n)
+	for i := 0; i < n; i++ {
+		var err error
+		polys[i], err = polyring.NewRand(t, rnd, c.p)
+		assert.Nil(test, err, "NewRand")
+
+		C[i] = c.NewG1()
+		c.Commit(C[i], polys[i])
+
+		x[i] = gmp.NewInt(0)
+		x[i].Rand(rnd, c.p)
+		y[i] = gmp.NewInt(0)
+		c.PolyEval(y[i], polys[i], x[i])
+		w[i] = c.NewG1()
+		c.CreateWitness(w[i], polys[i], x[i])
+
+		single[i] = gmp.NewInt(0)
+		c.PolyEval(single[i], polys[0], x[i])
+		singleW[i] = c.NewG1()
+		c.CreateWitness(singleW[i], polys[0], x[i])
+	}
+
+	ok, bad := c.BatchVerifyEval(C, x, y, w)
+	assert.True(test, ok, "BatchVerifyEval")
+	assert.Empty(test, bad)
+
+	ok, bad = c.BatchVerifyEval(C[:1], x, single, singleW)
+	assert.True(test, ok, "BatchVerifyEval single commitment")
+	assert.Empty(test, bad)
+
+	// wrong evaluations at 3 and 10, a wrong witness at 7
+	y[3].Add(y[3], gmp.NewInt(1))
+	y[10].Add(y[10], gmp.NewInt(1))
+	w[7].Set(w[6])
+
+	ok, bad = c.BatchVerifyEval(C, x, y, w)
+	assert.False(test, ok, "BatchVerifyEval")
+	assert.Equal(test, []int{3, 7, 10}, bad, "bad proofs")
+
+	single[0].Add(single[0], gmp.NewInt(1))
+	ok, bad = c.BatchVerifyEval(C[:1], x, single, singleW)
+	assert.False(test, ok, "BatchVerifyEval single commitment")
+	assert.Equal(test, []int{0}, bad, "bad proofs")
+}
+
+func BenchmarkDLPolyCommit_BatchVerifyEval(b *testing.B) {
+	const n = 32
+
+	c := new(DLPolyCommit)
+	c.SetupFix(bigPolyDegree)
+
+	poly100, err := polyring.NewRand(bigPolyDegree, rnd, c.p)
+	assert.Nil(b, err)
+
+	C := c.NewG1()
+	c.Commit(C, poly100)
+
+	x := make([]*gmp.Int, n)
+	y := make([]*gmp.Int, n)
+	w := make([]curve.Element, n)
+	for i := range x {
+		x[i] = gmp.NewInt(int64(i + 1))
+		y[i] = gmp.NewInt(0)
+		c.PolyEval(y[i], poly100, x[i])
+		w[i] = c.NewG1()
+		c.CreateWitness(w[i], poly100, x[i])
+	}
+
+	b.Run("VerifyEval", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := range x {
+				assert.True(b, c.VerifyEval(C, x[j], y[j], w[j]))
+			}
+		}
+	})
+
+	b.Run("BatchVerifyEval", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ok, _ := c.BatchVerifyEval([]curve.Element{C}, x, y, w)
+			assert.True(b, ok)
+		}
+	})
+}
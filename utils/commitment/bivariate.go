@@ -0,0 +1,24 @@
+package commitment
+
+import (
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/curve"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+)
+
+// CommitRows sets res[i] to g^B(x[i],alpha), the commitment to the row polynomial B(x[i],y)
+func (c *DLPolyCommit) CommitRows(res []curve.Element, bivar polyring.BivariatePolynomial, x []*gmp.Int) {
+	if len(res) != len(x) {
+		panic("mismatch length")
+	}
+
+	for i := range x {
+		c.Commit(res[i], bivar.Project(x[i], c.p))
+	}
+}
+
+// CreateRowWitness sets res to the witness of B(x,y) against the commitment to row B(x,.)
+// Node x hands (B(x,y), res) to node y, which checks it with VerifyEval and its own row at x
+func (c *DLPolyCommit) CreateRowWitness(res curve.Element, bivar polyring.BivariatePolynomial, x *gmp.Int, y *gmp.Int) {
+	c.CreateWitness(res, bivar.Project(x, c.p), y)
+}
@@ -0,0 +1,47 @@
+package commitment
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/curve"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDLPolyCommit_CommitRows(test *testing.T) {
+	c := new(DLPolyCommit)
+	const t = 5
+	const n = 8
+	rnd := rand.New(rand.NewSource(99))
+
+	c.SetupFix(t)
+
+	bivar, err := polyring.NewBivariateRand(t, rnd, c.p, gmp.NewInt(7))
+	assert.Nil(test, err, "NewBivariateRand")
+
+	x := make([]*gmp.Int, n)
+	C := make([]curve.Element, n)
+	for i := range x {
+		x[i] = gmp.NewInt(int64(i + 1))
+		C[i] = c.NewG1()
+	}
+
+	c.CommitRows(C, bivar, x)
+
+	for i := range x {
+		assert.True(test, c.VerifyPoly(C[i], bivar.Project(x[i], c.p)), "VerifyPoly")
+	}
+
+	// node i checks the point B(i,j) it receives from node j against j's row commitment
+	w := c.NewG1()
+	point := gmp.NewInt(0)
+	for i := range x {
+		for j := range x {
+			bivar.EvalMod(x[i], x[j], c.p, point)
+			c.CreateRowWitness(w, bivar, x[j], x[i])
+			assert.True(test, c.VerifyEval(C[j], x[i], point, w), "VerifyEval")
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package commitment
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/ncw/gmp"
+	curvebn254 "github.com/nikamn/BC-SSE/utils/curve/bn254"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDLPolyCommit_BN254(test *testing.T) {
+	const t = 6
+	rnd := rand.New(rand.NewSource(99))
+
+	// new setups are made on Curve
+	saved := Curve
+	defer func() { Curve = saved }()
+	Curve = curvebn254.BN254
+
+	srs, err := NewSRS(t, nil)
+	assert.Nil(test, err, "NewSRS")
+	assert.True(test, srs.Verify(), "Verify")
+
+	var buf bytes.Buffer
+	_, err = srs.WriteTo(&buf)
+	assert.Nil(test, err, "WriteTo")
+	decoded, err := ReadSRS(bytes.NewReader(buf.Bytes()))
+	assert.Nil(test, err, "ReadSRS")
+	for i := 0; i <= t; i++ {
+		assert.True(test, srs.GetPower(i).Equals(decoded.GetPower(i)), "power %d", i)
+		assert.True(test, srs.GetPower2(i).Equals(decoded.GetPower2(i)), "power in G2 %d", i)
+	}
+
+	c := new(DLPolyCommit)
+	c.SetupSRS(decoded)
+
+	poly, err := polyring.NewRand(t, rnd, c.p)
+	assert.Nil(test, err, "NewRand")
+
+	C := c.NewG1()
+	c.Commit(C, poly)
+
+	x := gmp.NewInt(0)
+	x.Rand(rnd, c.p)
+	polyOfX := gmp.NewInt(0)
+	c.PolyEval(polyOfX, poly, x)
+
+	w := c.NewG1()
+	c.CreateWitness(w, poly, x)
+	assert.True(test, c.VerifyEval(C, x, polyOfX, w), "VerifyEval")
+
+	// the same check as the ecPairing precompile
+	a, b := c.EvalPairingCheck(C, x, polyOfX, w)
+	product := c.curve.Pair(a[0], b[0])
+	product.Mul(product, c.curve.Pair(a[1], b[1]))
+	assert.True(test, product.Is1(), "EvalPairingCheck")
+	assert.Len(test, curvebn254.EVMPairingInput(a, b), 2*192)
+
+	polyOfX.Add(polyOfX, gmp.NewInt(1))
+	assert.False(test, c.VerifyEval(C, x, polyOfX, w), "VerifyEval wrong evaluation")
+	a, b = c.EvalPairingCheck(C, x, polyOfX, w)
+	product = c.curve.Pair(a[0], b[0])
+	product.Mul(product, c.curve.Pair(a[1], b[1]))
+	assert.False(test, product.Is1(), "EvalPairingCheck wrong evaluation")
+
+	// an SRS of one curve can't be read on another
+	Curve = saved
+	_, err = ReadSRS(bytes.NewReader(buf.Bytes()))
+	assert.NotNil(test, err, "ReadSRS on another curve")
+}
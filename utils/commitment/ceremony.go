@@ -0,0 +1,279 @@
+package commitment
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/nikamn/BC-SSE/utils/curve"
+)
+
+// A powers-of-tau ceremony builds the SRS sequentially: it starts from the SRS with trapdoor 1
+// (NewInitialSRS), and every participant multiplies the trapdoor by its own secret tau (Update).
+// The final trapdoor is unknown as long as one participant erased its tau. Anyone can check
+// the transcript of all updates (VerifyCeremony).
+
+// UpdateProof proves that an SRS was updated by a known secret tau
+type UpdateProof struct {
+	gTau curve.Element // g^tau
+	r    curve.Element // g^k, the Schnorr commitment
+	z    *big.Int      // k + c * tau, the Schnorr response
+}
+
+// NewInitialSRS returns the SRS on Curve with trapdoor 1, i.e. all powers are g
+func NewInitialSRS(degree int) (*SRS, error) {
+	if degree < 1 {
+		return nil, errors.New("degree must be positive")
+	}
+
+	return newSRS(Curve, degree, big.NewInt(1)), nil
+}
+
+// randomScalar returns a random integer in [1, r) where r is the order of cv, read from rnd or crypto/rand.Reader
+// if nil. The scalars are trapdoors and blindings, so rnd must be a CSPRNG outside of tests
+func randomScalar(cv curve.Curve, rnd io.Reader) (*big.Int, error) {
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+
+	k, err := rand.Int(rnd, new(big.Int).Sub(cv.Order(), big.NewInt(1)))
+	if err != nil {
+		return nil, err
+	}
+
+	return k.Add(k, big.NewInt(1)), nil
+}
+
+// Update returns the SRS re-randomized by a fresh secret tau, g^{s^i} -> g^{(s*tau)^i},
+// g2^{s^i} -> g2^{(s*tau)^i} and h^{s^i} -> h^{(s*tau)^i}, and a proof of the update.
+// tau is erased before returning
+func (srs *SRS) Update(rnd io.Reader) (*SRS, *UpdateProof, error) {
+	cv := srs.curve
+	order := cv.Order()
+
+	tau, err := randomScalar(cv, rnd)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer erase(tau)
+
+	next := &SRS{curve: cv, pk: make([]curve.Element, len(srs.pk))}
+	next.pk2 = next.pk
+	if !cv.Symmetric() {
+		next.pk2 = make([]curve.Element, len(srs.pk2))
+	}
+	if srs.hk != nil {
+		next.hk = make([]curve.Element, len(srs.hk))
+	}
+
+	// tmp = tau^i
+	tmp := big.NewInt(1)
+	defer erase(tmp)
+	for i := range srs.pk {
+		next.pk[i] = cv.NewG1().PowBig(srs.pk[i], tmp)
+		if !cv.Symmetric() {
+			next.pk2[i] = cv.NewG2().PowBig(srs.pk2[i], tmp)
+		}
+		if next.hk != nil {
+			next.hk[i] = cv.NewG1().PowBig(srs.hk[i], tmp)
+		}
+
+		tmp.Mul(tmp, tau)
+		tmp.Mod(tmp, order)
+	}
+
+	// Schnorr proof of knowledge of tau, bound to this update
+	k, err := randomScalar(cv, rnd)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer erase(k)
+
+	proof := &UpdateProof{
+		gTau: cv.NewG1().PowBig(cv.G1(), tau),
+		r:    cv.NewG1().PowBig(cv.G1(), k),
+		z:    new(big.Int),
+	}
+
+	c := updateChallenge(srs, next, proof)
+	proof.z.Mul(c, tau)
+	proof.z.Add(proof.z, k)
+	proof.z.Mod(proof.z, order)
+
+	return next, proof, nil
+}
+
+// WriteTo writes the proof as the compressed g^tau and g^k, followed by the fixed-width response
+func (proof *UpdateProof) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+
+	buf.Write(proof.gTau.Bytes())
+	buf.Write(proof.r.Bytes())
+
+	z := make([]byte, len(Curve.Order().Bytes()))
+	buf.Write(proof.z.FillBytes(z))
+
+	return buf.WriteTo(w)
+}
+
+// ReadUpdateProof reads a proof on Curve written by WriteTo
+func ReadUpdateProof(r io.Reader) (*UpdateProof, error) {
+	elementLen := Curve.G1Length()
+	buf := make([]byte, 2*elementLen+len(Curve.Order().Bytes()))
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+
+	proof := &UpdateProof{
+		gTau: Curve.NewG1(),
+		r:    Curve.NewG1(),
+		z:    new(big.Int).SetBytes(buf[2*elementLen:]),
+	}
+
+	if err := proof.gTau.SetBytes(buf[:elementLen]); err != nil {
+		return nil, err
+	}
+	if err := proof.r.SetBytes(buf[elementLen : 2*elementLen]); err != nil {
+		return nil, err
+	}
+
+	if proof.z.Cmp(Curve.Order()) >= 0 {
+		return nil, errors.New("response out of range")
+	}
+
+	return proof, nil
+}
+
+// updateChallenge returns the Fiat-Shamir challenge of the Schnorr proof
+func updateChallenge(prev, next *SRS, proof *UpdateProof) *big.Int {
+	h := sha256.New()
+	h.Write(prev.pk[1].Bytes())
+	h.Write(next.pk[1].Bytes())
+	h.Write(proof.gTau.Bytes())
+	h.Write(proof.r.Bytes())
+
+	c := new(big.Int).SetBytes(h.Sum(nil))
+
+	return c.Mod(c, prev.curve.Order())
+}
+
+// Verify checks that the powers are consistent, i.e. srs is g^{s^i}, g2^{s^i} and h^{s^i} for some s:
+// e(g^{s^{i+1}}, g2) == e(g^{s^i}, g2^s), e(h^{s^{i+1}}, g2) == e(h^{s^i}, g2^s)
+// and e(g, g2^{s^{i+1}}) == e(g^s, g2^{s^i}) for all i, batched with random linear combinations
+func (srs *SRS) Verify() bool {
+	cv := srs.curve
+	if len(srs.pk) < 2 || len(srs.pk2) != len(srs.pk) || !srs.pk[0].Equals(cv.G1()) || !srs.pk2[0].Equals(cv.G2()) || srs.pk[1].Is1() {
+		return false
+	}
+
+	if srs.hk != nil && (len(srs.hk) != len(srs.pk) || !srs.hk[0].Equals(hidingGenerator(cv))) {
+		return false
+	}
+
+	// lhs = prod (g^{s^{i+1}})^{r_i}, rhs = prod (g^{s^i})^{r_i}, and the same with h and with g2
+	lhs, rhs, tmp := cv.NewG1(), cv.NewG1(), cv.NewG1()
+	lhs2, rhs2, tmp2 := cv.NewG2(), cv.NewG2(), cv.NewG2()
+
+	for i := 0; i+1 < len(srs.pk); i++ {
+		r, err := randomScalar(cv, nil)
+		if err != nil {
+			return false
+		}
+
+		tmp.PowBig(srs.pk[i+1], r)
+		lhs.Mul(lhs, tmp)
+		tmp.PowBig(srs.pk[i], r)
+		rhs.Mul(rhs, tmp)
+
+		if !cv.Symmetric() {
+			tmp2.PowBig(srs.pk2[i+1], r)
+			lhs2.Mul(lhs2, tmp2)
+			tmp2.PowBig(srs.pk2[i], r)
+			rhs2.Mul(rhs2, tmp2)
+		}
+
+		if srs.hk == nil {
+			continue
+		}
+
+		r, err = randomScalar(cv, nil)
+		if err != nil {
+			return false
+		}
+
+		tmp.PowBig(srs.hk[i+1], r)
+		lhs.Mul(lhs, tmp)
+		tmp.PowBig(srs.hk[i], r)
+		rhs.Mul(rhs, tmp)
+	}
+
+	if !cv.Pair(lhs, srs.pk2[0]).Equals(cv.Pair(rhs, srs.pk2[1])) {
+		return false
+	}
+
+	if cv.Symmetric() {
+		return true
+	}
+
+	return cv.Pair(srs.pk[0], lhs2).Equals(cv.Pair(srs.pk[1], rhs2))
+}
+
+// VerifyUpdate checks that next is a well-formed SRS derived from prev by the secret proven in proof
+func VerifyUpdate(prev, next *SRS, proof *UpdateProof) bool {
+	cv := prev.curve
+	if next.curve.Name() != cv.Name() || len(prev.pk) != len(next.pk) || len(prev.hk) != len(next.hk) || proof.gTau.Is1() {
+		return false
+	}
+
+	// g^z == r * (g^tau)^c
+	c := updateChallenge(prev, next, proof)
+	lhs := cv.NewG1().PowBig(cv.G1(), proof.z)
+	rhs := cv.NewG1().PowBig(proof.gTau, c)
+	rhs.Mul(rhs, proof.r)
+	if !lhs.Equals(rhs) {
+		return false
+	}
+
+	// e(g^{s*tau}, g2) == e(g^tau, g2^s)
+	e1 := cv.Pair(next.pk[1], next.pk2[0])
+	e2 := cv.Pair(proof.gTau, prev.pk2[1])
+	if !e1.Equals(e2) {
+		return false
+	}
+
+	return next.Verify()
+}
+
+// VerifyCeremony checks a transcript srs[0], ..., srs[n] where srs[0] has trapdoor 1
+// and srs[i] was derived from srs[i-1] as proven by proofs[i-1]
+func VerifyCeremony(srs []*SRS, proofs []*UpdateProof) bool {
+	if len(srs) != len(proofs)+1 || len(proofs) == 0 {
+		return false
+	}
+
+	initial, err := NewInitialSRS(srs[0].GetDegree())
+	if err != nil {
+		return false
+	}
+
+	if srs[0].curve.Name() != initial.curve.Name() || len(srs[0].pk) != len(initial.pk) || len(srs[0].hk) != len(initial.hk) {
+		return false
+	}
+
+	for i := range initial.pk {
+		if !initial.pk[i].Equals(srs[0].pk[i]) || !initial.pk2[i].Equals(srs[0].pk2[i]) || !initial.hk[i].Equals(srs[0].hk[i]) {
+			return false
+		}
+	}
+
+	for i, proof := range proofs {
+		if !VerifyUpdate(srs[i], srs[i+1], proof) {
+			return false
+		}
+	}
+
+	return true
+}
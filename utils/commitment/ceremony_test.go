@@ -0,0 +1,71 @@
+package commitment
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/curve"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCeremony(test *testing.T) {
+	const t = 4
+	rnd := rand.New(rand.NewSource(99))
+
+	initial, err := NewInitialSRS(t)
+	assert.Nil(test, err, "NewInitialSRS")
+	assert.True(test, initial.Verify(), "initial")
+
+	transcript := []*SRS{initial}
+	var proofs []*UpdateProof
+	for i := 0; i < 3; i++ {
+		next, proof, err := transcript[i].Update(nil)
+		assert.Nil(test, err, "Update")
+		assert.True(test, VerifyUpdate(transcript[i], next, proof), "VerifyUpdate")
+
+		transcript = append(transcript, next)
+		proofs = append(proofs, proof)
+	}
+
+	assert.True(test, VerifyCeremony(transcript, proofs), "VerifyCeremony")
+
+	// a proof does not verify for another update
+	assert.False(test, VerifyUpdate(transcript[0], transcript[2], proofs[1]), "wrong predecessor")
+
+	// proofs survive serialization
+	var buf bytes.Buffer
+	_, err = proofs[2].WriteTo(&buf)
+	assert.Nil(test, err, "WriteTo")
+	decoded, err := ReadUpdateProof(&buf)
+	assert.Nil(test, err, "ReadUpdateProof")
+	assert.True(test, VerifyUpdate(transcript[2], transcript[3], decoded), "decoded proof")
+
+	// tampering with a single power is caught
+	final := transcript[3]
+	tampered := &SRS{curve: final.curve, pk: append([]curve.Element{}, final.pk...), pk2: final.pk2, hk: final.hk}
+	tampered.pk[t] = Curve.NewG1().Mul(final.pk[t], final.pk[t])
+	if Curve.Symmetric() {
+		tampered.pk2 = tampered.pk
+	}
+	assert.False(test, tampered.Verify(), "tampered power")
+	assert.False(test, VerifyCeremony(append(transcript[:3:3], tampered), proofs), "tampered transcript")
+
+	// the final SRS is usable
+	c := new(DLPolyCommit)
+	c.SetupSRS(final)
+
+	poly, err := polyring.NewRand(t, rnd, c.p)
+	assert.Nil(test, err, "NewRand")
+
+	x := gmp.NewInt(12345)
+	polyOfX := gmp.NewInt(0)
+	C := c.NewG1()
+	w := c.NewG1()
+	c.Commit(C, poly)
+	c.PolyEval(polyOfX, poly, x)
+	c.CreateWitness(w, poly, x)
+	assert.True(test, c.VerifyEval(C, x, polyOfX, w), "VerifyEval")
+}
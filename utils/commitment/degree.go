@@ -0,0 +1,40 @@
+package commitment
+
+import (
+	"fmt"
+
+	"github.com/nikamn/BC-SSE/utils/curve"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+)
+
+// A degree-bound proof shifts the committed polynomial up to the setup degree D:
+// proof = g^(alpha^(D-d) polyring(alpha)). It can only be computed from the powers g^(alpha^i), i <= D,
+// if polyring has degree at most d, and is checked with e(C, g2^(alpha^(D-d))) == e(proof, g2).
+
+// CreateDegreeProof sets res to the proof that polynomial has degree at most d
+func (c *DLPolyCommit) CreateDegreeProof(res curve.Element, polynomial polyring.Polynomial, d int) error {
+	if d < 0 || d > c.degree {
+		return fmt.Errorf("degree bound must be in [0, %d], got %d", c.degree, d)
+	}
+
+	if polynomial.GetDegree() > d {
+		return fmt.Errorf("polynomial has degree %d > %d", polynomial.GetDegree(), d)
+	}
+
+	// res = prod (g^(alpha^(i+D-d)))^ci
+	c.evalInExponent(res, c.NewG1(), c.pk[c.degree-d:], polynomial)
+
+	return nil
+}
+
+// VerifyDegree checks that proof shows the polynomial committed to by C has degree at most d
+func (c *DLPolyCommit) VerifyDegree(C curve.Element, d int, proof curve.Element) bool {
+	if d < 0 || d > c.degree {
+		return false
+	}
+
+	e1 := c.curve.Pair(C, c.pk2[c.degree-d])
+	e2 := c.curve.Pair(proof, c.pk2[0])
+
+	return e1.Equals(e2)
+}
@@ -0,0 +1,44 @@
+package commitment
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/nikamn/BC-SSE/utils/polyring"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDLPolyCommit_Degree(test *testing.T) {
+	c := new(DLPolyCommit)
+	const t = 8
+	rnd := rand.New(rand.NewSource(99))
+
+	c.SetupFix(t)
+
+	for _, degree := range []int{0, 3, t} {
+		poly, err := polyring.NewRand(degree, rnd, c.p)
+		assert.Nil(test, err, "NewRand")
+
+		C := c.NewG1()
+		c.Commit(C, poly)
+
+		for d := degree; d <= t; d++ {
+			proof := c.NewG1()
+			assert.Nil(test, c.CreateDegreeProof(proof, poly, d), "CreateDegreeProof")
+			assert.True(test, c.VerifyDegree(C, d, proof), "VerifyDegree %d <= %d", degree, d)
+		}
+
+		if degree > 0 {
+			proof := c.NewG1()
+			assert.NotNil(test, c.CreateDegreeProof(proof, poly, degree-1), "degree too high")
+
+			// the best a cheating dealer can do with the SRS: shift by one power less
+			c.CreateDegreeProof(proof, poly, degree)
+			assert.False(test, c.VerifyDegree(C, degree-1, proof), "VerifyDegree %d <= %d", degree, degree-1)
+		}
+	}
+
+	poly, _ := polyring.NewRand(2, rnd, c.p)
+	assert.NotNil(test, c.CreateDegreeProof(c.NewG1(), poly, t+1), "bound above the setup degree")
+	assert.False(test, c.VerifyDegree(c.NewG1(), -1, c.NewG1()), "negative bound")
+}
@@ -3,45 +3,52 @@ package commitment
 import (
 	"math/big"
 
-	"github.com/Nik-U/pbc"
 	"github.com/ncw/gmp"
-	//"github.com/nikamn/ChuRP/src/utils/ecparam"
+	"github.com/nikamn/BC-SSE/utils/curve"
+	curvepbc "github.com/nikamn/BC-SSE/utils/curve/pbc"
+	"github.com/nikamn/BC-SSE/utils/ecparam"
 )
 
-//var Curve = ecparam.PBC256
-
 // DLCommit for x is g^x
 type DLCommit struct {
-	pairing *pbc.Pairing
-	pk      *pbc.Element
+	curve curve.Curve
+	pk    curve.Element
 }
 
-// Setup initializes a DLCommit.
-// group order is 2^rbits, finite field is F_q where q is ~2^qbits.
+// Setup initializes a DLCommit with freshly generated Type A parameters.
+// group order is ~2^rbits, finite field is F_q where q is ~2^qbits.
 // suggested parameters are rbits = 160, qbits = 512
-func (c *DLCommit) Setup(rbits, qbits uint32) {
-	panic("unimplemented")
+func (c *DLCommit) Setup(rbits, qbits uint32) error {
+	pp, err := ecparam.GenerateA(rbits, qbits)
+	if err != nil {
+		return err
+	}
+
+	c.curve = curvepbc.New(pp, "pbc-a-generated")
+	c.pk = c.curve.G1()
+
+	return nil
 }
 
 // SetupFix initializes a fixed DLCommit
 func (c *DLCommit) SetupFix() {
-	c.pairing = Curve.Pairing
-	c.pk = Curve.G
+	c.curve = Curve
+	c.pk = Curve.G1()
 }
 
 // NewG1 generates New G1
-func (c *DLCommit) NewG1() *pbc.Element {
-	return c.pairing.NewG1()
+func (c *DLCommit) NewG1() curve.Element {
+	return c.curve.NewG1()
 }
 
 // NewGT generates New GT
-func (c *DLCommit) NewGT() *pbc.Element {
-	return c.pairing.NewGT()
+func (c *DLCommit) NewGT() curve.Element {
+	return c.curve.NewGT()
 }
 
 // Commit sets res to g^x
-func (c *DLCommit) Commit(res *pbc.Element, x *gmp.Int) {
-	if c.pairing == nil || c.pk == nil {
+func (c *DLCommit) Commit(res curve.Element, x *gmp.Int) {
+	if c.curve == nil || c.pk == nil {
 		panic("not initialized")
 	}
 	exp := big.NewInt(0)
@@ -50,11 +57,11 @@ func (c *DLCommit) Commit(res *pbc.Element, x *gmp.Int) {
 }
 
 // Verify checks C == g^x
-func (c *DLCommit) Verify(C *pbc.Element, x *gmp.Int) bool {
-	if c.pairing == nil || c.pk == nil {
+func (c *DLCommit) Verify(C curve.Element, x *gmp.Int) bool {
+	if c.curve == nil || c.pk == nil {
 		panic("not initialized")
 	}
-	tmp := c.pairing.NewG1()
+	tmp := c.curve.NewG1()
 	exp := big.NewInt(0)
 	exp.SetString(x.String(), 10)
 	tmp.PowBig(c.pk, exp)
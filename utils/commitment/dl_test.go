@@ -12,9 +12,23 @@ func TestDLCommit(t *testing.T) {
 	c.SetupFix()
 
 	// res = g^x
-	res := c.pairing.NewG1()
+	res := c.NewG1()
 	x := gmp.NewInt(100)
 	c.Commit(res, x)
 
 	assert.True(t, c.Verify(res, x), "dl_commit")
 }
+
+func TestDLCommit_Setup(t *testing.T) {
+	c := DLCommit{}
+	err := c.Setup(160, 512)
+	assert.Nil(t, err, "Setup")
+
+	res := c.NewG1()
+	x := gmp.NewInt(100)
+	c.Commit(res, x)
+
+	assert.True(t, c.Verify(res, x), "dl_commit")
+	assert.False(t, c.Verify(res, gmp.NewInt(101)), "dl_commit")
+	assert.False(t, res.Equals(c.NewG1().Set1()), "fresh parameters")
+}
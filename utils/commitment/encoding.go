@@ -0,0 +1,180 @@
+package commitment
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/nikamn/BC-SSE/utils/curve"
+)
+
+// srsJSON is the JSON encoding of an SRS, with the compressed powers in hex
+type srsJSON struct {
+	Curve  string   `json:"curve"`
+	G1     []string `json:"g1"`
+	G2     []string `json:"g2,omitempty"` // omitted if the pairing is symmetric
+	Hiding []string `json:"hiding,omitempty"`
+}
+
+// MarshalBinary returns the SRS as written by WriteTo
+func (srs *SRS) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := srs.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary sets the SRS to the one encoded by MarshalBinary, on Curve
+func (srs *SRS) UnmarshalBinary(data []byte) error {
+	decoded, err := ReadSRS(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	*srs = *decoded
+	return nil
+}
+
+// MarshalJSON returns the SRS as a JSON object with the name of the curve and the powers in hex
+func (srs *SRS) MarshalJSON() ([]byte, error) {
+	enc := srsJSON{
+		Curve:  srs.curve.Name(),
+		G1:     hexElements(srs.pk),
+		Hiding: hexElements(srs.hk),
+	}
+	if !srs.curve.Symmetric() {
+		enc.G2 = hexElements(srs.pk2)
+	}
+	return json.Marshal(enc)
+}
+
+// UnmarshalJSON sets the SRS to the one encoded by MarshalJSON, on Curve
+func (srs *SRS) UnmarshalJSON(data []byte) error {
+	var enc srsJSON
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return err
+	}
+
+	if enc.Curve != Curve.Name() {
+		return fmt.Errorf("SRS is for curve %s, not %s", enc.Curve, Curve.Name())
+	}
+
+	if len(enc.G1) < 2 {
+		return errors.New("SRS too short")
+	}
+
+	if len(enc.Hiding) != 0 && len(enc.Hiding) != len(enc.G1) {
+		return errors.New("number of hiding powers mismatch")
+	}
+
+	decoded := &SRS{curve: Curve}
+
+	var err error
+	if decoded.pk, err = decodePowers(enc.G1, Curve.NewG1); err != nil {
+		return err
+	}
+
+	decoded.pk2 = decoded.pk
+	if !Curve.Symmetric() {
+		if len(enc.G2) != len(enc.G1) {
+			return errors.New("number of powers in G2 mismatch")
+		}
+		if decoded.pk2, err = decodePowers(enc.G2, Curve.NewG2); err != nil {
+			return err
+		}
+	}
+
+	if len(enc.Hiding) != 0 {
+		if decoded.hk, err = decodePowers(enc.Hiding, Curve.NewG1); err != nil {
+			return err
+		}
+	}
+
+	*srs = *decoded
+	return nil
+}
+
+// hexElements returns the elements in hex
+func hexElements(elements []curve.Element) []string {
+	if elements == nil {
+		return nil
+	}
+	res := make([]string, len(elements))
+	for i := range elements {
+		res[i] = curve.Hex(elements[i])
+	}
+	return res
+}
+
+// decodePowers decodes powers in hex into new elements, which must not be the identity
+func decodePowers(encoded []string, newElement func() curve.Element) ([]curve.Element, error) {
+	powers := make([]curve.Element, len(encoded))
+	for i := range encoded {
+		powers[i] = newElement()
+		if err := curve.SetHex(powers[i], encoded[i]); err != nil {
+			return nil, fmt.Errorf("power %d: %v", i, err)
+		}
+
+		if powers[i].Is1() {
+			return nil, fmt.Errorf("power %d is the identity", i)
+		}
+	}
+	return powers, nil
+}
+
+// srs returns the SRS the commitment scheme was set up with
+func (c *DLPolyCommit) srs() *SRS {
+	return &SRS{curve: c.curve, pk: c.pk, pk2: c.pk2, hk: c.hk}
+}
+
+// MarshalBinary returns the public parameters of the commitment scheme, encoded as their SRS
+func (c *DLPolyCommit) MarshalBinary() ([]byte, error) {
+	return c.srs().MarshalBinary()
+}
+
+// UnmarshalBinary initializes the commitment scheme from public parameters encoded by MarshalBinary
+func (c *DLPolyCommit) UnmarshalBinary(data []byte) error {
+	srs := new(SRS)
+	if err := srs.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	c.SetupSRS(srs)
+	return nil
+}
+
+// MarshalJSON returns the public parameters of the commitment scheme, encoded as their SRS
+func (c *DLPolyCommit) MarshalJSON() ([]byte, error) {
+	return c.srs().MarshalJSON()
+}
+
+// UnmarshalJSON initializes the commitment scheme from public parameters encoded by MarshalJSON
+func (c *DLPolyCommit) UnmarshalJSON(data []byte) error {
+	srs := new(SRS)
+	if err := srs.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	c.SetupSRS(srs)
+	return nil
+}
+
+// MarshalG1 returns a commitment or witness as a JSON string of its compressed form in hex.
+// The binary encoding is the compressed form itself, see curve.Element.Bytes
+func MarshalG1(x curve.Element) ([]byte, error) {
+	return json.Marshal(curve.Hex(x))
+}
+
+// UnmarshalG1 decodes a commitment or witness encoded by MarshalG1 into a new element of G1
+func (c *DLPolyCommit) UnmarshalG1(data []byte) (curve.Element, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+
+	res := c.NewG1()
+	if err := curve.SetHex(res, s); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
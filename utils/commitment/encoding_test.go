@@ -0,0 +1,108 @@
+package commitment
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSRS_Encoding(test *testing.T) {
+	const t = 4
+	srs, err := NewSRS(t, nil)
+	assert.Nil(test, err, "NewSRS")
+
+	data, err := srs.MarshalBinary()
+	assert.Nil(test, err, "MarshalBinary")
+	fromBinary := new(SRS)
+	assert.Nil(test, fromBinary.UnmarshalBinary(data), "UnmarshalBinary")
+
+	data, err = json.Marshal(srs)
+	assert.Nil(test, err, "MarshalJSON")
+	fromJSON := new(SRS)
+	assert.Nil(test, json.Unmarshal(data, fromJSON), "UnmarshalJSON")
+
+	for _, decoded := range []*SRS{fromBinary, fromJSON} {
+		assert.Equal(test, t, decoded.GetDegree())
+		for i := 0; i <= t; i++ {
+			assert.True(test, srs.GetPower(i).Equals(decoded.GetPower(i)), "power %d", i)
+			assert.True(test, srs.GetPower2(i).Equals(decoded.GetPower2(i)), "power in G2 %d", i)
+			assert.True(test, srs.GetHidingPower(i).Equals(decoded.GetHidingPower(i)), "hiding power %d", i)
+		}
+	}
+
+	var enc srsJSON
+	assert.Nil(test, json.Unmarshal(data, &enc))
+
+	tampered := enc
+	tampered.Curve = "another"
+	b, _ := json.Marshal(tampered)
+	assert.NotNil(test, json.Unmarshal(b, new(SRS)), "another curve")
+
+	tampered = enc
+	tampered.G1 = append([]string{}, enc.G1...)
+	tampered.G1[1] = tampered.G1[1][:4]
+	b, _ = json.Marshal(tampered)
+	assert.NotNil(test, json.Unmarshal(b, new(SRS)), "invalid power")
+
+	tampered = enc
+	tampered.Hiding = enc.Hiding[:t]
+	b, _ = json.Marshal(tampered)
+	assert.NotNil(test, json.Unmarshal(b, new(SRS)), "hiding powers mismatch")
+}
+
+func TestDLPolyCommit_Encoding(test *testing.T) {
+	const t = 5
+	rnd := rand.New(rand.NewSource(99))
+
+	c := new(DLPolyCommit)
+	c.SetupFix(t)
+
+	poly, err := polyring.NewRand(t, rnd, c.p)
+	assert.Nil(test, err, "NewRand")
+
+	C := c.NewG1()
+	c.Commit(C, poly)
+
+	x := gmp.NewInt(7)
+	y := gmp.NewInt(0)
+	w := c.NewG1()
+	c.PolyEval(y, poly, x)
+	c.CreateWitness(w, poly, x)
+
+	// the public parameters, the commitment and the witness are all a verifier needs
+	params, err := json.Marshal(c)
+	assert.Nil(test, err, "MarshalJSON")
+	commitmentJSON, err := MarshalG1(C)
+	assert.Nil(test, err, "MarshalG1")
+	witnessJSON, err := MarshalG1(w)
+	assert.Nil(test, err, "MarshalG1")
+
+	verifier := new(DLPolyCommit)
+	assert.Nil(test, json.Unmarshal(params, verifier), "UnmarshalJSON")
+	C2, err := verifier.UnmarshalG1(commitmentJSON)
+	assert.Nil(test, err, "UnmarshalG1")
+	w2, err := verifier.UnmarshalG1(witnessJSON)
+	assert.Nil(test, err, "UnmarshalG1")
+
+	assert.True(test, C2.Equals(C), "commitment")
+	assert.True(test, verifier.VerifyEval(C2, x, y, w2), "VerifyEval")
+	assert.True(test, verifier.VerifyPoly(C2, poly), "VerifyPoly")
+
+	// the binary encoding
+	params, err = c.MarshalBinary()
+	assert.Nil(test, err, "MarshalBinary")
+	verifier = new(DLPolyCommit)
+	assert.Nil(test, verifier.UnmarshalBinary(params), "UnmarshalBinary")
+	w3 := verifier.NewG1()
+	assert.Nil(test, w3.SetBytes(w.Bytes()), "SetBytes")
+	assert.True(test, verifier.VerifyEval(C, x, y, w3), "VerifyEval")
+
+	_, err = verifier.UnmarshalG1([]byte(`"00"`))
+	assert.NotNil(test, err, "invalid element")
+	_, err = verifier.UnmarshalG1([]byte(`7`))
+	assert.NotNil(test, err, "not a string")
+}
@@ -0,0 +1,124 @@
+package commitment
+
+import (
+	"fmt"
+
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/conv"
+	"github.com/nikamn/BC-SSE/utils/curve"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+)
+
+// Amortized witnesses for all points of a multiplicative subgroup, after Feist and Khovratovich,
+// "Fast amortized KZG proofs". For polynomial f of degree d and z = omega^i the witness is
+//   g^q_z(alpha) = prod_k H_k^(z^k),  H_k = g^(f_{k+1} + f_{k+2} alpha + ... + f_d alpha^(d-k-1))
+// so all witnesses are the DFT of (H_0, ..., H_{d-1}) in the group. The H_k are a Toeplitz
+// matrix-vector product, which is computed as a circulant convolution with FFTs.
+
+// groupFFT sets a to its DFT in the exponent, a[i] = prod_j a[j]^(omega^(ij)).
+// len(a) must be a power of two and omega a primitive len(a)-th root of unity
+func (c *DLPolyCommit) groupFFT(a []curve.Element, omega *gmp.Int) {
+	n := len(a)
+	polyring.BitReverse(n, func(i, j int) { a[i], a[j] = a[j], a[i] })
+
+	wm, w := gmp.NewInt(0), gmp.NewInt(0)
+	t := c.NewG1()
+	for m := 2; m <= n; m <<= 1 {
+		wm.Exp(omega, gmp.NewInt(int64(n/m)), c.p)
+
+		for k := 0; k < n; k += m {
+			w.SetInt64(1)
+			for j := 0; j < m/2; j++ {
+				t.PowBig(a[k+j+m/2], conv.GmpInt2BigInt(w))
+
+				a[k+j+m/2].Div(a[k+j], t)
+				a[k+j].Mul(a[k+j], t)
+
+				w.Mul(w, wm)
+				w.Mod(w, c.p)
+			}
+		}
+	}
+}
+
+// CreateAllWitnesses sets res[i] to the witness of polynomial at omega^i, for i = 0, ..., n-1 where n = len(res),
+// as CreateWitness would. omega is polyring.RootOfUnity(n, p); n must be a power of two no smaller than
+// the degree of polynomial. This takes O(n log n) group operations instead of O(n * degree)
+func (c *DLPolyCommit) CreateAllWitnesses(res []curve.Element, polynomial polyring.Polynomial) error {
+	n := len(res)
+	d := polynomial.GetDegree()
+
+	if d > c.degree {
+		return fmt.Errorf("degree %d exceeds the setup degree %d", d, c.degree)
+	}
+
+	if !polyring.IsPowerOfTwo(n) || n < d {
+		return fmt.Errorf("number of points must be a power of two no smaller than %d, got %d", d, n)
+	}
+
+	// a constant has the identity as every witness
+	if d == 0 {
+		for i := range res {
+			res[i].Set1()
+		}
+		return nil
+	}
+
+	// H_k = (f * R)_{d+k} where R_t = g^(alpha^(d-1-t)), computed as a cyclic convolution of size N >= 2d
+	N := 1
+	for N < 2*d {
+		N <<= 1
+	}
+
+	omegaN, err := polyring.RootOfUnity(N, c.p)
+	if err != nil {
+		return err
+	}
+
+	R := make([]curve.Element, N)
+	for t := range R {
+		R[t] = c.NewG1()
+		if t < d {
+			R[t].Set(c.pk[d-1-t])
+		}
+	}
+	c.groupFFT(R, omegaN)
+
+	// coefficients scaled by 1/N, so that the inverse transform needs no scaling
+	nInv := gmp.NewInt(0)
+	nInv.ModInverse(gmp.NewInt(int64(N)), c.p)
+
+	f := make([]*gmp.Int, N)
+	polyring.VecInit(f)
+	for j := 0; j <= d; j++ {
+		coeff, _ := polynomial.GetCoefficient(j)
+		f[j].Mul(&coeff, nInv)
+		f[j].Mod(f[j], c.p)
+	}
+	polyring.NTT(f, omegaN, c.p)
+
+	for i := range R {
+		R[i].PowBig(R[i], conv.GmpInt2BigInt(f[i]))
+	}
+
+	omegaNInv := gmp.NewInt(0)
+	omegaNInv.ModInverse(omegaN, c.p)
+	c.groupFFT(R, omegaNInv)
+
+	// the witnesses are the DFT of H, padded to n
+	omega, err := polyring.RootOfUnity(n, c.p)
+	if err != nil {
+		return err
+	}
+
+	for k := range res {
+		if k < d {
+			res[k].Set(R[d+k])
+		} else {
+			res[k].Set1()
+		}
+	}
+	c.groupFFT(res, omega)
+
+	return nil
+}
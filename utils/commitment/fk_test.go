@@ -0,0 +1,96 @@
+package commitment
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/curve"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDLPolyCommit_CreateAllWitnesses(test *testing.T) {
+	c := new(DLPolyCommit)
+	const t = 6
+	rnd := rand.New(rand.NewSource(99))
+
+	c.SetupFix(t)
+
+	for _, degree := range []int{0, 1, 5, t} {
+		poly, err := polyring.NewRand(degree, rnd, c.p)
+		assert.Nil(test, err, "NewRand")
+
+		C := c.NewG1()
+		c.Commit(C, poly)
+
+		for _, n := range []int{8, 16} {
+			res := make([]curve.Element, n)
+			for i := range res {
+				res[i] = c.NewG1()
+			}
+
+			err = c.CreateAllWitnesses(res, poly)
+			assert.Nil(test, err, "CreateAllWitnesses")
+
+			omega, err := polyring.RootOfUnity(n, c.p)
+			assert.Nil(test, err, "RootOfUnity")
+
+			x := gmp.NewInt(1)
+			polyOfX := gmp.NewInt(0)
+			w := c.NewG1()
+			for i := range res {
+				c.CreateWitness(w, poly, x)
+				assert.True(test, w.Equals(res[i]), "same as CreateWitness at omega^%d", i)
+
+				c.PolyEval(polyOfX, poly, x)
+				assert.True(test, c.VerifyEval(C, x, polyOfX, res[i]), "VerifyEval")
+
+				x.Mul(x, omega)
+				x.Mod(x, c.p)
+			}
+		}
+	}
+
+	poly, _ := polyring.NewRand(t, rnd, c.p)
+	err := c.CreateAllWitnesses(make([]curve.Element, 4), poly)
+	assert.NotNil(test, err, "fewer points than the degree")
+	err = c.CreateAllWitnesses(make([]curve.Element, 12), poly)
+	assert.NotNil(test, err, "not a power of two")
+}
+
+func BenchmarkDLPolyCommit_CreateAllWitnesses(b *testing.B) {
+	const n = 64
+
+	c := new(DLPolyCommit)
+	c.SetupFix(n - 1)
+
+	poly, err := polyring.NewRand(n-1, rnd, c.p)
+	assert.Nil(b, err)
+
+	omega, err := polyring.RootOfUnity(n, c.p)
+	assert.Nil(b, err)
+
+	res := make([]curve.Element, n)
+	for i := range res {
+		res[i] = c.NewG1()
+	}
+
+	// the loop of owner.go, one CreateWitness per point
+	b.Run("CreateWitness", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x := gmp.NewInt(1)
+			for j := range res {
+				c.CreateWitness(res[j], poly, x)
+				x.Mul(x, omega)
+				x.Mod(x, c.p)
+			}
+		}
+	})
+
+	b.Run("CreateAllWitnesses", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			assert.Nil(b, c.CreateAllWitnesses(res, poly))
+		}
+	})
+}
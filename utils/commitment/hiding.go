@@ -0,0 +1,116 @@
+package commitment
+
+import (
+	"crypto/sha256"
+	"errors"
+	"io"
+
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/conv"
+	"github.com/nikamn/BC-SSE/utils/curve"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+)
+
+// Hiding commitments, after Kate, Zaverucha and Goldberg (PolyCommit_Ped):
+// C = g^polyring(alpha) h^blind(alpha) for a random blinding polynomial of the setup degree.
+// C is uniformly distributed whatever polyring is, and an evaluation proof at x reveals
+// polyring(x) and blind(x) only, so any degree points can be opened without leaking the rest.
+// log_g h must be unknown, otherwise the commitment is not binding.
+
+var hidingSeed = sha256.Sum256([]byte("BC-SSE KZG hiding generator"))
+
+// hidingGenerator returns h, a generator of G1 with unknown discrete logarithm to base g, obtained by hashing to the curve
+func hidingGenerator(cv curve.Curve) curve.Element {
+	return cv.HashToG1(hidingSeed[:])
+}
+
+// IsHiding returns if the setup has the powers of h, needed by the hiding functions
+func (c *DLPolyCommit) IsHiding() bool {
+	return c.hk != nil
+}
+
+// NewBlinding returns a random blinding polynomial of the setup degree, with coefficients from rnd,
+// crypto/rand.Reader if nil
+func (c *DLPolyCommit) NewBlinding(rnd io.Reader) (polyring.Polynomial, error) {
+	blind, err := polyring.New(c.degree)
+	if err != nil {
+		return polyring.Polynomial{}, err
+	}
+
+	for i := 0; i <= c.degree; i++ {
+		ci, err := randomScalar(c.curve, rnd)
+		if err != nil {
+			return polyring.Polynomial{}, err
+		}
+
+		blind.SetCoefficientBig(i, conv.BigInt2GmpInt(ci))
+		erase(ci)
+	}
+
+	return blind, nil
+}
+
+// CommitHiding sets res to g^polyring(alpha) h^blind(alpha)
+func (c *DLPolyCommit) CommitHiding(res curve.Element, poly polyring.Polynomial, blind polyring.Polynomial) error {
+	if !c.IsHiding() {
+		return errors.New("setup has no hiding powers")
+	}
+
+	tmp := c.NewG1()
+	c.PolyEvalInExponent(res, poly)
+	c.evalInExponent(tmp, c.NewG1(), c.hk, blind)
+	res.Mul(res, tmp)
+
+	return nil
+}
+
+// VerifyPolyHiding checks C == g^polyring(alpha) h^blind(alpha)
+func (c *DLPolyCommit) VerifyPolyHiding(C curve.Element, poly polyring.Polynomial, blind polyring.Polynomial) bool {
+	tmp := c.NewG1()
+	if c.CommitHiding(tmp, poly, blind) != nil {
+		return false
+	}
+
+	return tmp.Equals(C)
+}
+
+// CreateWitnessHiding sets res to g^phi(alpha) h^phiHat(alpha) where phi(x) = (polyring(x)-polyring(x0)) / (x - x0)
+// and phiHat(x) = (blind(x)-blind(x0)) / (x - x0). The proof opens to polyring(x0) and blind(x0)
+func (c *DLPolyCommit) CreateWitnessHiding(res curve.Element, polynomial polyring.Polynomial, blind polyring.Polynomial, x0 *gmp.Int) error {
+	if !c.IsHiding() {
+		return errors.New("setup has no hiding powers")
+	}
+
+	tmp := c.NewG1()
+	c.PolyEvalInExponent(res, c.witnessPoly(polynomial, x0))
+	c.evalInExponent(tmp, c.NewG1(), c.hk, c.witnessPoly(blind, x0))
+	res.Mul(res, tmp)
+
+	return nil
+}
+
+// VerifyEvalHiding checks that w proves polyring(x) == polyX and blind(x) == blindX against C
+// e(C / (g^polyX h^blindX), g2) == e(w, g2^alpha / g2^x)
+func (c *DLPolyCommit) VerifyEvalHiding(C curve.Element, x *gmp.Int, polyX *gmp.Int, blindX *gmp.Int, w curve.Element) bool {
+	if !c.IsHiding() {
+		return false
+	}
+
+	// t1 = C / (g^polyX h^blindX)
+	t1 := c.NewG1()
+	tmp := c.NewG1()
+	t1.PowBig(c.pk[0], c.exponent(polyX))
+	tmp.PowBig(c.hk[0], c.exponent(blindX))
+	t1.Mul(t1, tmp)
+	t1.Div(C, t1)
+
+	// t2 = g2^alpha / g2^x
+	t2 := c.NewG2()
+	t2.PowBig(c.pk2[0], c.exponent(x))
+	t2.Div(c.pk2[1], t2)
+
+	e1 := c.curve.Pair(t1, c.pk2[0])
+	e2 := c.curve.Pair(w, t2)
+
+	return e1.Equals(e2)
+}
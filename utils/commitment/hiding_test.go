@@ -0,0 +1,119 @@
+package commitment
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDLPolyCommit_Hiding(test *testing.T) {
+	c := new(DLPolyCommit)
+	const t = 5
+	rnd := rand.New(rand.NewSource(99))
+
+	c.SetupFix(t)
+	assert.True(test, c.IsHiding(), "SetupFix")
+
+	poly, err := polyring.NewRand(t, rnd, c.p)
+	assert.Nil(test, err, "NewRand")
+
+	blind, err := c.NewBlinding(nil)
+	assert.Nil(test, err, "NewBlinding")
+	assert.Equal(test, t, blind.GetDegree())
+
+	C := c.NewG1()
+	assert.Nil(test, c.CommitHiding(C, poly, blind), "CommitHiding")
+	assert.True(test, c.VerifyPolyHiding(C, poly, blind), "VerifyPolyHiding")
+
+	// the same polynomial under another blinding gives another commitment
+	blind2, err := c.NewBlinding(nil)
+	assert.Nil(test, err, "NewBlinding")
+	C2 := c.NewG1()
+	assert.Nil(test, c.CommitHiding(C2, poly, blind2), "CommitHiding")
+	assert.False(test, C.Equals(C2), "commitments with different blindings")
+	assert.False(test, c.VerifyPolyHiding(C, poly, blind2), "VerifyPolyHiding with another blinding")
+
+	plain := c.NewG1()
+	c.Commit(plain, poly)
+	assert.False(test, C.Equals(plain), "hiding and plain commitments")
+
+	for i := 0; i < 3; i++ {
+		x := gmp.NewInt(0)
+		x.Rand(rnd, c.p)
+
+		polyOfX, blindOfX := gmp.NewInt(0), gmp.NewInt(0)
+		c.PolyEval(polyOfX, poly, x)
+		c.PolyEval(blindOfX, blind, x)
+
+		w := c.NewG1()
+		assert.Nil(test, c.CreateWitnessHiding(w, poly, blind, x), "CreateWitnessHiding")
+		assert.True(test, c.VerifyEvalHiding(C, x, polyOfX, blindOfX, w), "VerifyEvalHiding")
+
+		wrong := gmp.NewInt(0)
+		wrong.Add(polyOfX, gmp.NewInt(1))
+		assert.False(test, c.VerifyEvalHiding(C, x, wrong, blindOfX, w), "wrong evaluation")
+		wrong.Add(blindOfX, gmp.NewInt(1))
+		assert.False(test, c.VerifyEvalHiding(C, x, polyOfX, wrong, w), "wrong blinding evaluation")
+		assert.False(test, c.VerifyEvalHiding(C2, x, polyOfX, blindOfX, w), "another commitment")
+	}
+}
+
+func TestSRS_Hiding(test *testing.T) {
+	const t = 4
+	rnd := rand.New(rand.NewSource(99))
+
+	srs, err := NewSRS(t, nil)
+	assert.Nil(test, err, "NewSRS")
+	assert.True(test, srs.GetHidingPower(0).Equals(hidingGenerator(Curve)), "h")
+	assert.True(test, srs.Verify(), "Verify")
+
+	var buf bytes.Buffer
+	_, err = srs.WriteTo(&buf)
+	assert.Nil(test, err, "WriteTo")
+
+	decoded, err := ReadSRS(bytes.NewReader(buf.Bytes()))
+	assert.Nil(test, err, "ReadSRS")
+	for i := 0; i <= t; i++ {
+		assert.True(test, srs.GetHidingPower(i).Equals(decoded.GetHidingPower(i)), "hiding power %d", i)
+	}
+
+	// hiding powers with another trapdoor
+	decoded.hk[2].Set(decoded.hk[1])
+	assert.False(test, decoded.Verify(), "Verify inconsistent hiding powers")
+
+	// an SRS without hiding powers still works for plain commitments
+	plain := &SRS{curve: srs.curve, pk: srs.pk, pk2: srs.pk2}
+	buf.Reset()
+	_, err = plain.WriteTo(&buf)
+	assert.Nil(test, err, "WriteTo")
+	decoded, err = ReadSRS(bytes.NewReader(buf.Bytes()))
+	assert.Nil(test, err, "ReadSRS")
+	assert.Nil(test, decoded.GetHidingPower(0))
+	assert.True(test, decoded.Verify(), "Verify")
+
+	c := new(DLPolyCommit)
+	c.SetupSRS(decoded)
+	assert.False(test, c.IsHiding(), "SetupSRS without hiding powers")
+
+	c.SetupSRS(srs)
+	assert.True(test, c.IsHiding(), "SetupSRS")
+
+	poly, err := polyring.NewRand(t, rnd, c.p)
+	assert.Nil(test, err, "NewRand")
+	blind, err := c.NewBlinding(nil)
+	assert.Nil(test, err, "NewBlinding")
+
+	x := gmp.NewInt(7)
+	polyOfX, blindOfX := gmp.NewInt(0), gmp.NewInt(0)
+	c.PolyEval(polyOfX, poly, x)
+	c.PolyEval(blindOfX, blind, x)
+
+	C, w := c.NewG1(), c.NewG1()
+	assert.Nil(test, c.CommitHiding(C, poly, blind), "CommitHiding")
+	assert.Nil(test, c.CreateWitnessHiding(w, poly, blind, x), "CreateWitnessHiding")
+	assert.True(test, c.VerifyEvalHiding(C, x, polyOfX, blindOfX, w), "VerifyEvalHiding")
+}
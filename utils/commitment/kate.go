@@ -1,32 +1,48 @@
 package commitment
 
 import (
+	"crypto/sha256"
+	"errors"
 	"fmt"
 	"math/big"
 
-	"github.com/Nik-U/pbc"
 	"github.com/ncw/gmp"
 	"github.com/nikamn/BC-SSE/utils/conv"
-	"github.com/nikamn/BC-SSE/utils/ecparam"
+	"github.com/nikamn/BC-SSE/utils/curve"
+	curvepbc "github.com/nikamn/BC-SSE/utils/curve/pbc"
+	"github.com/nikamn/BC-SSE/utils/interpolation"
 	"github.com/nikamn/BC-SSE/utils/polyring"
 )
 
 // DLPolyCommit struct
 type DLPolyCommit struct {
-	pairing *pbc.Pairing
-	pk      []*pbc.Power
-	degree  int
-	p       *gmp.Int
+	curve  curve.Curve
+	pk     []curve.Element // g^{alpha^i} in G1
+	pk2    []curve.Element // g2^{alpha^i} in G2, the same as pk if the pairing is symmetric
+	hk     []curve.Element // h^{alpha^i} for hiding commitments, nil if the setup has none
+	degree int
+	p      *gmp.Int
+	field  *polyring.Field // Z_p, the field of the exponents
 }
 
 // NewG1 generates New G1
-func (c *DLPolyCommit) NewG1() *pbc.Element {
-	return c.pairing.NewG1()
+func (c *DLPolyCommit) NewG1() curve.Element {
+	return c.curve.NewG1()
+}
+
+// NewG2 generates New G2
+func (c *DLPolyCommit) NewG2() curve.Element {
+	return c.curve.NewG2()
 }
 
 // NewGT generates New GT
-func (c *DLPolyCommit) NewGT() *pbc.Element {
-	return c.pairing.NewGT()
+func (c *DLPolyCommit) NewGT() curve.Element {
+	return c.curve.NewGT()
+}
+
+// GetCurve returns the curve of the setup
+func (c *DLPolyCommit) GetCurve() curve.Curve {
+	return c.curve
 }
 
 // PolyEval sets res to polyring(x)
@@ -35,12 +51,24 @@ func (c *DLPolyCommit) PolyEval(res *gmp.Int, poly polyring.Polynomial, x *gmp.I
 	poly.EvalMod(x, c.p, res)
 }
 
+// exponent returns x mod p as a big.Int, taking the sign of x into account
+func (c *DLPolyCommit) exponent(x *gmp.Int) *big.Int {
+	reduced := gmp.NewInt(0)
+	reduced.Mod(x, c.p)
+	return conv.GmpInt2BigInt(reduced)
+}
+
 // PolyEvalInExponent sets res to g^polyring(alpha)
 // Let polyring(x)=c0 + c1*x + ... cn * x^n
-func (c *DLPolyCommit) PolyEvalInExponent(res *pbc.Element, poly polyring.Polynomial) {
+func (c *DLPolyCommit) PolyEvalInExponent(res curve.Element, poly polyring.Polynomial) {
+	c.evalInExponent(res, c.NewG1(), c.pk, poly)
+}
+
+// evalInExponent sets res to prod pk[i]^ci, i.e. g^polyring(alpha) for the powers of g or h^polyring(alpha) for those of h.
+// tmp is scratch space in the group of pk
+func (c *DLPolyCommit) evalInExponent(res curve.Element, tmp curve.Element, pk []curve.Element, poly polyring.Polynomial) {
 	// res = 1
 	res.Set1()
-	tmp := c.pairing.NewG1()
 	for i := 0; i <= poly.GetDegree(); i++ {
 		// tmp = g^{a^i} ^ ci
 		ci, err := poly.GetCoefficient(i)
@@ -48,7 +76,7 @@ func (c *DLPolyCommit) PolyEvalInExponent(res *pbc.Element, poly polyring.Polyno
 			panic("can't get coeff i")
 		}
 
-		c.pk[i].PowBig(tmp, conv.GmpInt2BigInt(&ci))
+		tmp.PowBig(pk[i], c.exponent(&ci))
 		res.Mul(res, tmp)
 	}
 }
@@ -56,138 +84,208 @@ func (c *DLPolyCommit) PolyEvalInExponent(res *pbc.Element, poly polyring.Polyno
 // PrintPublicKey prints the public keys
 func (c *DLPolyCommit) PrintPublicKey() {
 	for i := 0; i <= c.degree; i++ {
-		fmt.Printf("g^(SK^%d): %s\n", i, c.pk[i].Source().String())
+		fmt.Printf("g^(SK^%d): %s\n", i, c.pk[i].String())
 	}
 }
 
-// Curve sets parameters from ecparam PBC256
-var Curve = ecparam.PBC256
+// Curve is the pairing used by new setups, the Type A curve of ecparam PBC256 by default
+var Curve curve.Curve = curvepbc.PBC256
 
 // SetupFix initializes a fixed pairing
+// The trapdoor is the constant 2, so it is only fit for tests. Use NewSRS and SetupSRS instead
 func (c *DLPolyCommit) SetupFix(degree int) {
-	c.degree = degree
-
-	// setup the pairing
-	c.pairing = Curve.Pairing
-	c.p = Curve.Ngmp
-
-	// trusted setup
-	c.pk = make([]*pbc.Power, degree+1)
-
-	// a generator g
-	g := Curve.G
-
-	// secret key
-	sk := new(big.Int)
-	sk.SetString("2", 10)
-
-	tmp := new(big.Int)
-	for i := 0; i <= degree; i++ {
-		// tmp = sk ^ i
-		bigP := big.NewInt(0)
-		bigP.SetString(c.p.String(), 10)
-		tmp.Exp(sk, big.NewInt(int64(i)), bigP)
-		// pk[i] = g ^ tmp
-		// Search pk and modify them all
-		inter := c.pairing.NewG1()
-		c.pk[i] = inter.PowBig(g, tmp).PreparePower()
-	}
+	c.SetupFix2(degree, "2")
 }
 
 // SetupFix2 initializes a fixed pairing for user input given key
+// Whoever knows the key can forge proofs, so it is only fit for tests. Use NewSRS and SetupSRS instead
 func (c *DLPolyCommit) SetupFix2(degree int, key string) {
-	c.degree = degree
-
-	// setup the pairing
-	c.pairing = Curve.Pairing
-	c.p = Curve.Ngmp
-
-	// trusted setup
-	c.pk = make([]*pbc.Power, degree+1)
-
-	// a generator g
-	g := Curve.G
-
 	// secret key
 	sk := new(big.Int)
 	sk.SetString(key, 10)
 
-	tmp := new(big.Int)
-	for i := 0; i <= degree; i++ {
-		// tmp = sk ^ i
-		bigP := big.NewInt(0)
-		bigP.SetString(c.p.String(), 10)
-		tmp.Exp(sk, big.NewInt(int64(i)), bigP)
-		// pk[i] = g ^ tmp
-		// Search pk and modify them all
-		inter := c.pairing.NewG1()
-		c.pk[i] = inter.PowBig(g, tmp).PreparePower()
-	}
+	c.SetupSRS(newSRS(Curve, degree, sk))
 }
 
 // Commit sets res to g^polyring(alpha)
-func (c *DLPolyCommit) Commit(res *pbc.Element, poly polyring.Polynomial) {
+func (c *DLPolyCommit) Commit(res curve.Element, poly polyring.Polynomial) {
 	c.PolyEvalInExponent(res, poly)
 }
 
-// Open is not used
-func (c *DLPolyCommit) Open() {
-	panic("unimplemented")
+// Opening is the full polynomial behind a commitment, with a proof that it is the committed one
+type Opening struct {
+	Poly  polyring.Polynomial
+	Proof curve.Element // witness of Poly at the challenge point
+}
+
+// openChallenge returns the Fiat-Shamir point z = H(C, poly) mod p at which an opening is proven
+func (c *DLPolyCommit) openChallenge(C curve.Element, poly polyring.Polynomial) *gmp.Int {
+	h := sha256.New()
+	h.Write(C.Bytes())
+	for i := 0; i <= poly.GetDegree(); i++ {
+		ci, _ := poly.GetCoefficient(i)
+		b := ci.Bytes()
+		h.Write([]byte{byte(len(b))})
+		h.Write(b)
+	}
+
+	z := gmp.NewInt(0)
+	z.SetBytes(h.Sum(nil))
+	z.Mod(z, c.p)
+
+	return z
+}
+
+// Open returns the opening of the commitment to polynomial: the polynomial reduced mod p, and the witness
+// of its evaluation at z = H(C, polynomial). If a different polynomial were committed to, the two would only
+// agree at z with negligible probability, so VerifyOpen needs two pairings instead of VerifyPoly's degree+1
+// exponentiations
+func (c *DLPolyCommit) Open(polynomial polyring.Polynomial) *Opening {
+	poly := polynomial.DeepCopy()
+	c.field.Reduce(&poly)
+
+	C := c.NewG1()
+	c.Commit(C, poly)
+
+	opening := &Opening{Poly: poly, Proof: c.NewG1()}
+	c.CreateWitness(opening.Proof, poly, c.openChallenge(C, poly))
+
+	return opening
+}
+
+// VerifyOpen checks that opening is the polynomial committed to by C
+func (c *DLPolyCommit) VerifyOpen(C curve.Element, opening *Opening) bool {
+	if opening == nil || opening.Proof == nil || opening.Poly.GetDegree() > c.degree {
+		return false
+	}
+
+	poly := opening.Poly.DeepCopy()
+	c.field.Reduce(&poly)
+
+	z := c.openChallenge(C, poly)
+	polyOfZ := gmp.NewInt(0)
+	c.PolyEval(polyOfZ, poly, z)
+
+	return c.VerifyEval(C, z, polyOfZ, opening.Proof)
 }
 
 // VerifyPoly checks C == g ^ polyring(alpha)
-func (c *DLPolyCommit) VerifyPoly(C *pbc.Element, poly polyring.Polynomial) bool {
-	tmp := c.pairing.NewG1()
+func (c *DLPolyCommit) VerifyPoly(C curve.Element, poly polyring.Polynomial) bool {
+	tmp := c.NewG1()
 	c.PolyEvalInExponent(tmp, poly)
 	return tmp.Equals(C)
 }
 
 // CreateWitness sets res to g ^ phi(alpha) where phi(x) = (polyring(x)-polyring(x0)) / (x - x0)
-func (c *DLPolyCommit) CreateWitness(res *pbc.Element, polynomial polyring.Polynomial, x0 *gmp.Int) {
-	polyT := polynomial.DeepCopy()
-
-	// tmp = polynomial(x0)
-	tmp := new(gmp.Int)
-	c.PolyEval(tmp, polyT, x0)
-	// fmt.Printf("CreateWitness\n%s\n%s\n", polynomial.String(), tmp.String())
-
-	// polyT = polynomial(x)-polynomial(x0)
-	polyT.GetPtrToConstant().Sub(polyT.GetPtrToConstant(), tmp)
+func (c *DLPolyCommit) CreateWitness(res curve.Element, polynomial polyring.Polynomial, x0 *gmp.Int) {
+	c.PolyEvalInExponent(res, c.witnessPoly(polynomial, x0))
+}
 
-	// quot == polyT / (x - x0)
+// witnessPoly returns phi(x) = (polyring(x)-polyring(x0)) / (x - x0)
+func (c *DLPolyCommit) witnessPoly(polynomial polyring.Polynomial, x0 *gmp.Int) polyring.Polynomial {
+	// the remainder of polynomial(x) / (x - x0) is polynomial(x0), so the quotient is phi(x)
 	quot := polyring.NewEmpty()
 
 	// denominator = x - x0
-	denominator, err := polyring.New(1)
-	if err != nil {
-		panic("can't create polyring")
-	}
-	// FIXME: converting to int64 is dangerous
-	denominator.SetCoefficient(1, 1)
+	denominator := polyring.FromVec(0, 1)
 	denominator.GetPtrToConstant().Neg(x0)
 
-	quot.Div2(polyT, denominator)
-	// fmt.Printf("CreateWitness2\n%s\n", quot.String())
+	if err := c.field.Div2(&quot, polynomial, denominator); err != nil {
+		panic("can't divide by x - x0: " + err.Error())
+	}
 
-	c.PolyEvalInExponent(res, quot)
+	return quot
 }
 
 // VerifyEval checks the correctness of w, returns true/false
-func (c *DLPolyCommit) VerifyEval(C *pbc.Element, x *gmp.Int, polyX *gmp.Int, w *pbc.Element) bool {
-	e1 := c.pairing.NewGT()
-	e2 := c.pairing.NewGT()
-	t1 := c.pairing.NewGT()
-	t2 := c.pairing.NewG1()
-	e1.Pair(C, c.pk[0].Source())
-	exp := big.NewInt(0)
-	exp.SetString(x.String(), 10)
-	c.pk[0].PowBig(t2, exp)
-	t2.Div(c.pk[1].Source(), t2)
-	e2.Pair(w, t2)
-	t1.Pair(c.pk[0].Source(), c.pk[0].Source())
-	exp.SetString(polyX.String(), 10)
-	t1.PowBig(t1, exp)
-	e2.Mul(e2, t1)
+// e(C / g^polyX, g2) == e(w, g2^alpha / g2^x)
+func (c *DLPolyCommit) VerifyEval(C curve.Element, x *gmp.Int, polyX *gmp.Int, w curve.Element) bool {
+	t1 := c.NewG1()
+	t1.PowBig(c.pk[0], c.exponent(polyX))
+	t1.Div(C, t1)
+
+	t2 := c.NewG2()
+	t2.PowBig(c.pk2[0], c.exponent(x))
+	t2.Div(c.pk2[1], t2)
+
+	e1 := c.curve.Pair(t1, c.pk2[0])
+	e2 := c.curve.Pair(w, t2)
 	// fmt.Printf("e1\n%s\ne2\n%s\n", e1.String(), e2.String())
 	return e1.Equals(e2)
 }
+
+// EvalPairingCheck returns the pairs (a[i], b[i]) such that w proves polyring(x) == polyX against C
+// iff e(a[0], b[0]) e(a[1], b[1]) == 1, which is the check done by the ecPairing precompile of the EVM
+// on the BN254 curve. a = (C g^-polyX w^x, w^-1), b = (g2, g2^alpha)
+func (c *DLPolyCommit) EvalPairingCheck(C curve.Element, x *gmp.Int, polyX *gmp.Int, w curve.Element) ([]curve.Element, []curve.Element) {
+	a0, tmp := c.NewG1(), c.NewG1()
+	a0.PowBig(c.pk[0], c.exponent(polyX))
+	a0.Div(C, a0)
+	tmp.PowBig(w, c.exponent(x))
+	a0.Mul(a0, tmp)
+
+	a1 := c.NewG1()
+	a1.Div(a1, w)
+
+	return []curve.Element{a0, a1}, []curve.Element{c.pk2[0], c.pk2[1]}
+}
+
+// vanishing returns Z(x) = (x - xs[0]) ... (x - xs[n-1]) mod p
+func vanishing(xs []*gmp.Int, field *polyring.Field) polyring.Polynomial {
+	return field.FromRoots(xs)
+}
+
+// CreateMultiWitness sets res to g ^ q(alpha), a single witness for the evaluations of polynomial at all xs,
+// where q(x) = (polynomial(x) - I(x)) / Z(x), I interpolates polynomial at xs and Z vanishes on xs
+func (c *DLPolyCommit) CreateMultiWitness(res curve.Element, polynomial polyring.Polynomial, xs []*gmp.Int) error {
+	if len(xs) == 0 || len(xs) > c.degree {
+		return fmt.Errorf("number of points must be in [1, %d], got %d", c.degree, len(xs))
+	}
+
+	ys := make([]*gmp.Int, len(xs))
+	polyring.VecInit(ys)
+	polynomial.EvalModArray(xs, c.p, ys)
+
+	inter, err := interpolation.LagrangeInterpolate(len(xs)-1, xs, ys, c.p)
+	if err != nil {
+		return err
+	}
+
+	// polyT = polynomial(x) - I(x)
+	polyT := polyring.NewEmpty()
+	c.field.Sub(&polyT, polynomial, inter)
+
+	quot := polyring.NewEmpty()
+	if err := c.field.Div(&quot, polyT, vanishing(xs, c.field)); err != nil {
+		return errors.New("internal error: polynomial - I is not divisible by Z")
+	}
+
+	c.PolyEvalInExponent(res, quot)
+
+	return nil
+}
+
+// VerifyMultiEval checks that w proves polynomial(xs[i]) == ys[i] for all i against C, returns true/false
+// e(C / g^I(alpha), g2) == e(w, g2^Z(alpha))
+func (c *DLPolyCommit) VerifyMultiEval(C curve.Element, xs []*gmp.Int, ys []*gmp.Int, w curve.Element) bool {
+	if len(xs) == 0 || len(xs) > c.degree || len(xs) != len(ys) {
+		return false
+	}
+
+	inter, err := interpolation.LagrangeInterpolate(len(xs)-1, xs, ys, c.p)
+	if err != nil {
+		return false
+	}
+
+	gI := c.NewG1()
+	c.PolyEvalInExponent(gI, inter)
+	gI.Div(C, gI)
+
+	gZ := c.NewG2()
+	c.evalInExponent(gZ, c.NewG2(), c.pk2, vanishing(xs, c.field))
+
+	e1 := c.curve.Pair(gI, c.pk2[0])
+	e2 := c.curve.Pair(w, gZ)
+
+	return e1.Equals(e2)
+}
@@ -30,8 +30,8 @@ func TestDLCommit_Commit(test *testing.T) {
 	x.Rand(rnd, p)
 	polyOfX := new(gmp.Int)
 
-	C := c.pairing.NewG1()
-	w := c.pairing.NewG1()
+	C := c.NewG1()
+	w := c.NewG1()
 
 	// Test PolyCommit
 	c.Commit(C, poly)
@@ -64,8 +64,8 @@ func TestDLCommit_Large(test *testing.T) {
 	x.Rand(rnd, p)
 	polyOfX := new(gmp.Int)
 
-	C := c.pairing.NewG1()
-	w := c.pairing.NewG1()
+	C := c.NewG1()
+	w := c.NewG1()
 
 	// Test PolyCommit
 	c.Commit(C, poly)
@@ -78,6 +78,48 @@ func TestDLCommit_Large(test *testing.T) {
 	assert.True(test, c.VerifyEval(C, x, polyOfX, w), "VerifyEval")
 }
 
+func TestDLPolyCommit_MultiEval(test *testing.T) {
+	c := new(DLPolyCommit)
+	const t = 8
+	rnd := rand.New(rand.NewSource(99))
+
+	c.SetupFix(t)
+
+	poly, err := polyring.NewRand(t, rnd, c.p)
+	assert.Nil(test, err, "NewRand")
+
+	C := c.NewG1()
+	c.Commit(C, poly)
+
+	for _, n := range []int{1, 3, t} {
+		xs := make([]*gmp.Int, n)
+		ys := make([]*gmp.Int, n)
+		polyring.VecInit(ys)
+		// the trapdoor of SetupFix is 2, which must not be one of the points
+		for i := range xs {
+			xs[i] = gmp.NewInt(int64(i + 10))
+		}
+		poly.EvalModArray(xs, c.p, ys)
+
+		w := c.NewG1()
+		err = c.CreateMultiWitness(w, poly, xs)
+		assert.Nil(test, err, "CreateMultiWitness")
+		assert.True(test, c.VerifyMultiEval(C, xs, ys, w), "VerifyMultiEval")
+
+		// a wrong evaluation is rejected
+		ys[n-1].Add(ys[n-1], gmp.NewInt(1))
+		assert.False(test, c.VerifyMultiEval(C, xs, ys, w), "wrong evaluation")
+	}
+
+	// Z has degree len(xs), so at most t points fit the setup
+	xs := make([]*gmp.Int, t+1)
+	for i := range xs {
+		xs[i] = gmp.NewInt(int64(i + 1))
+	}
+	err = c.CreateMultiWitness(c.NewG1(), poly, xs)
+	assert.NotNil(test, err, "too many points")
+}
+
 const bigPolyDegree = 100
 
 var rnd = rand.New(rand.NewSource(time.Now().UTC().UnixNano()))
@@ -95,8 +137,8 @@ func BenchmarkDLPolyCommit_VerifyEval(b *testing.B) {
 	x.Rand(rnd, c.p)
 	polyOfX := new(gmp.Int)
 
-	C := c.pairing.NewG1()
-	w := c.pairing.NewG1()
+	C := c.NewG1()
+	w := c.NewG1()
 
 	// Test PolyCommit
 	c.Commit(C, poly100)
@@ -111,3 +153,32 @@ func BenchmarkDLPolyCommit_VerifyEval(b *testing.B) {
 		}
 	})
 }
+
+func TestDLPolyCommit_Open(test *testing.T) {
+	c := new(DLPolyCommit)
+	const t = 5
+	rnd := rand.New(rand.NewSource(99))
+
+	c.SetupFix(t)
+
+	poly, err := polyring.NewRand(t, rnd, c.p)
+	assert.Nil(test, err, "NewRand")
+
+	C := c.NewG1()
+	c.Commit(C, poly)
+
+	opening := c.Open(poly)
+	assert.True(test, opening.Poly.IsSame(poly), "opened polynomial")
+	assert.True(test, c.VerifyOpen(C, opening), "VerifyOpen")
+
+	// another polynomial with the same proof
+	other := poly.DeepCopy()
+	other.GetPtrToConstant().Add(other.GetPtrToConstant(), gmp.NewInt(1))
+	assert.False(test, c.VerifyOpen(C, &Opening{Poly: other, Proof: opening.Proof}), "VerifyOpen another polynomial")
+
+	// another commitment
+	C2 := c.NewG1()
+	c.Commit(C2, other)
+	assert.False(test, c.VerifyOpen(C2, opening), "VerifyOpen another commitment")
+	assert.True(test, c.VerifyOpen(C2, c.Open(other)), "VerifyOpen")
+}
@@ -0,0 +1,359 @@
+package commitment
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/curve"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+)
+
+// A keyword set S is encoded as the polynomial P(x) = prod (x - h(w)) over w in S, h being KeywordPoint,
+// and committed to as C = g^P(alpha). A keyword w is in S iff P(h(w)) = 0, which is proven by the witness
+// of that evaluation. A set W of keywords is disjoint from S iff gcd(P, Z_W) = 1, Z_W vanishing on h(W),
+// which is proven by the Bezout coefficients s P + t Z_W = 1 in the exponent: e(C, g2^s(alpha)) e(g^t(alpha), g2^Z_W(alpha)) == e(g, g2)
+// h is a PRF keyed by the KeywordKey of the owner and the clients, so that without the key the commitment
+// can't be checked against guessed keyword sets. Documents with the same keyword set still have the same commitment
+
+// keywordDomain separates the keyword hashes from the other hashes into Z_p
+const keywordDomain = "BC-SSE keyword"
+
+// KeywordKeyLength is the length in bytes of a KeywordKey
+const KeywordKeyLength = 32
+
+// KeywordKey is the secret key of the keyword PRF, shared by the owner and the clients that query the index
+type KeywordKey []byte
+
+// NewKeywordKey returns a random key read from rnd, crypto/rand.Reader if nil
+func NewKeywordKey(rnd io.Reader) (KeywordKey, error) {
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+
+	key := make(KeywordKey, KeywordKeyLength)
+	if _, err := io.ReadFull(rnd, key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// KeywordPoint returns h(keyword) = HMAC-SHA256(key, keyword) mod p, the root encoding keyword
+func (c *DLPolyCommit) KeywordPoint(key KeywordKey, keyword string) *gmp.Int {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(keywordDomain))
+	h.Write([]byte(keyword))
+
+	x := gmp.NewInt(0)
+	x.SetBytes(h.Sum(nil))
+	x.Mod(x, c.p)
+
+	return x
+}
+
+// keywordPoints returns the distinct points of keywords
+func (c *DLPolyCommit) keywordPoints(key KeywordKey, keywords []string) []*gmp.Int {
+	seen := make(map[string]bool)
+	var points []*gmp.Int
+	for _, w := range keywords {
+		x := c.KeywordPoint(key, w)
+		if key := x.String(); !seen[key] {
+			seen[key] = true
+			points = append(points, x)
+		}
+	}
+	return points
+}
+
+// KeywordPoly returns the polynomial whose roots are the points of keywords, 1 for no keywords.
+// Repeated keywords are counted once, and there can be at most degree distinct keywords
+func (c *DLPolyCommit) KeywordPoly(key KeywordKey, keywords []string) (polyring.Polynomial, error) {
+	if len(key) < KeywordKeyLength {
+		return polyring.Polynomial{}, fmt.Errorf("key must have at least %d bytes, got %d", KeywordKeyLength, len(key))
+	}
+
+	points := c.keywordPoints(key, keywords)
+	if len(points) > c.degree {
+		return polyring.Polynomial{}, fmt.Errorf("at most %d keywords, got %d", c.degree, len(points))
+	}
+
+	if len(points) == 0 {
+		return polyring.NewOne(), nil
+	}
+
+	return c.field.FromRoots(points), nil
+}
+
+// KeywordIndex is the index of the keyword set of a document: the polynomial encoding the set,
+// kept by the owner, and its commitment, published to the clients
+type KeywordIndex struct {
+	c          *DLPolyCommit
+	key        KeywordKey
+	poly       polyring.Polynomial
+	Commitment curve.Element
+}
+
+// NewKeywordIndex returns the index of the keyword set, with the points of the keywords keyed by key
+func (c *DLPolyCommit) NewKeywordIndex(key KeywordKey, keywords []string) (*KeywordIndex, error) {
+	poly, err := c.KeywordPoly(key, keywords)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := &KeywordIndex{c: c, key: key, poly: poly, Commitment: c.NewG1()}
+	c.Commit(idx.Commitment, poly)
+
+	return idx, nil
+}
+
+// Contains returns if keyword is in the set
+func (idx *KeywordIndex) Contains(keyword string) bool {
+	y := gmp.NewInt(0)
+	idx.c.field.Eval(y, idx.poly, idx.c.KeywordPoint(idx.key, keyword))
+	return y.CmpInt32(0) == 0
+}
+
+// ProveMembership sets res to the proof that all keywords are in the set: the witness of P(h(w)) = 0
+// for a single keyword, the multi-witness of all the evaluations otherwise
+func (idx *KeywordIndex) ProveMembership(res curve.Element, keywords []string) error {
+	points := idx.c.keywordPoints(idx.key, keywords)
+	if len(points) == 0 {
+		return errors.New("no keywords")
+	}
+
+	for _, w := range keywords {
+		if !idx.Contains(w) {
+			return fmt.Errorf("keyword %q is not in the set", w)
+		}
+	}
+
+	if len(points) == 1 {
+		idx.c.CreateWitness(res, idx.poly, points[0])
+		return nil
+	}
+
+	return idx.c.CreateMultiWitness(res, idx.poly, points)
+}
+
+// VerifyMembership checks that w proves all keywords are in the set committed to by C with key
+func (c *DLPolyCommit) VerifyMembership(C curve.Element, key KeywordKey, keywords []string, w curve.Element) bool {
+	points := c.keywordPoints(key, keywords)
+	if len(points) == 0 || w == nil {
+		return false
+	}
+
+	zeros := make([]*gmp.Int, len(points))
+	polyring.VecInit(zeros)
+
+	if len(points) == 1 {
+		return c.VerifyEval(C, points[0], zeros[0], w)
+	}
+
+	return c.VerifyMultiEval(C, points, zeros, w)
+}
+
+// NonMembershipProof shows that no keyword of a set W is in the committed set,
+// with the Bezout coefficients s P + t Z_W = 1 in the exponent
+type NonMembershipProof struct {
+	S curve.Element // g2^s(alpha) in G2
+	T curve.Element // g^t(alpha) in G1
+}
+
+// ProveNonMembership returns the proof that none of keywords is in the set
+func (idx *KeywordIndex) ProveNonMembership(keywords []string) (*NonMembershipProof, error) {
+	points := idx.c.keywordPoints(idx.key, keywords)
+	if len(points) == 0 || len(points) > idx.c.degree {
+		return nil, fmt.Errorf("number of keywords must be in [1, %d], got %d", idx.c.degree, len(points))
+	}
+
+	g, s, t := idx.c.field.ExtendedGCD(idx.poly, vanishing(points, idx.c.field))
+	if g.GetDegree() != 0 {
+		return nil, errors.New("some keyword is in the set")
+	}
+
+	// deg s < deg Z_W and deg t < deg P, so both are within the setup degree
+	proof := &NonMembershipProof{S: idx.c.NewG2(), T: idx.c.NewG1()}
+	idx.c.evalInExponent(proof.S, idx.c.NewG2(), idx.c.pk2, s)
+	idx.c.PolyEvalInExponent(proof.T, t)
+
+	return proof, nil
+}
+
+// VerifyNonMembership checks that proof shows none of keywords is in the set committed to by C with key
+// e(C, g2^s(alpha)) e(g^t(alpha), g2^Z_W(alpha)) == e(g, g2)
+func (c *DLPolyCommit) VerifyNonMembership(C curve.Element, key KeywordKey, keywords []string, proof *NonMembershipProof) bool {
+	points := c.keywordPoints(key, keywords)
+	if len(points) == 0 || len(points) > c.degree || proof == nil || proof.S == nil || proof.T == nil {
+		return false
+	}
+
+	gZ := c.NewG2()
+	c.evalInExponent(gZ, c.NewG2(), c.pk2, vanishing(points, c.field))
+
+	e1 := c.curve.Pair(C, proof.S)
+	e1.Mul(e1, c.curve.Pair(proof.T, gZ))
+
+	return e1.Equals(c.curve.Pair(c.pk[0], c.pk2[0]))
+}
+
+// nonMembershipJSON is the JSON encoding of a NonMembershipProof, with the compressed elements in hex
+type nonMembershipJSON struct {
+	S string `json:"s"`
+	T string `json:"t"`
+}
+
+// MarshalJSON returns the proof as a JSON object with the elements in hex
+func (proof *NonMembershipProof) MarshalJSON() ([]byte, error) {
+	return json.Marshal(nonMembershipJSON{S: curve.Hex(proof.S), T: curve.Hex(proof.T)})
+}
+
+// UnmarshalNonMembershipProof decodes a proof encoded by MarshalJSON into new elements
+func (c *DLPolyCommit) UnmarshalNonMembershipProof(data []byte) (*NonMembershipProof, error) {
+	var enc nonMembershipJSON
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return nil, err
+	}
+
+	proof := &NonMembershipProof{S: c.NewG2(), T: c.NewG1()}
+	if err := curve.SetHex(proof.S, enc.S); err != nil {
+		return nil, fmt.Errorf("s: %v", err)
+	}
+	if err := curve.SetHex(proof.T, enc.T); err != nil {
+		return nil, fmt.Errorf("t: %v", err)
+	}
+
+	return proof, nil
+}
+
+// QueryProof answers a query: the keywords of the query that are in the set, with the proof of their
+// membership, and those that are not, with the proof of their non-membership
+type QueryProof struct {
+	Present       []string
+	Absent        []string
+	Membership    curve.Element       // nil if Present is empty
+	NonMembership *NonMembershipProof // nil if Absent is empty
+}
+
+// ProveQuery splits keywords into those in the set and the others, and proves both
+func (idx *KeywordIndex) ProveQuery(keywords []string) (*QueryProof, error) {
+	if len(keywords) == 0 {
+		return nil, errors.New("no keywords")
+	}
+
+	proof := new(QueryProof)
+	seen := make(map[string]bool)
+	for _, w := range keywords {
+		if seen[w] {
+			continue
+		}
+		seen[w] = true
+
+		if idx.Contains(w) {
+			proof.Present = append(proof.Present, w)
+		} else {
+			proof.Absent = append(proof.Absent, w)
+		}
+	}
+
+	if len(proof.Present) > 0 {
+		proof.Membership = idx.c.NewG1()
+		if err := idx.ProveMembership(proof.Membership, proof.Present); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(proof.Absent) > 0 {
+		var err error
+		if proof.NonMembership, err = idx.ProveNonMembership(proof.Absent); err != nil {
+			return nil, err
+		}
+	}
+
+	return proof, nil
+}
+
+// VerifyQuery checks that proof splits keywords into those in the set committed to by C with key and the others
+func (c *DLPolyCommit) VerifyQuery(C curve.Element, key KeywordKey, keywords []string, proof *QueryProof) bool {
+	if proof == nil || len(proof.Present)+len(proof.Absent) == 0 {
+		return false
+	}
+
+	// every keyword of the query is answered once, and only those
+	answered := make(map[string]bool)
+	for _, w := range append(append([]string{}, proof.Present...), proof.Absent...) {
+		if answered[w] {
+			return false
+		}
+		answered[w] = true
+	}
+	queried := make(map[string]bool)
+	for _, w := range keywords {
+		if !answered[w] {
+			return false
+		}
+		queried[w] = true
+	}
+	if len(queried) != len(answered) {
+		return false
+	}
+
+	if len(proof.Present) > 0 && !c.VerifyMembership(C, key, proof.Present, proof.Membership) {
+		return false
+	}
+
+	return len(proof.Absent) == 0 || c.VerifyNonMembership(C, key, proof.Absent, proof.NonMembership)
+}
+
+// queryJSON is the JSON encoding of a QueryProof, with the compressed elements in hex
+type queryJSON struct {
+	Present       []string        `json:"present,omitempty"`
+	Absent        []string        `json:"absent,omitempty"`
+	Membership    string          `json:"membership,omitempty"`
+	NonMembership json.RawMessage `json:"nonMembership,omitempty"`
+}
+
+// MarshalJSON returns the proof as a JSON object with the elements in hex
+func (proof *QueryProof) MarshalJSON() ([]byte, error) {
+	enc := queryJSON{Present: proof.Present, Absent: proof.Absent}
+	if proof.Membership != nil {
+		enc.Membership = curve.Hex(proof.Membership)
+	}
+	if proof.NonMembership != nil {
+		var err error
+		if enc.NonMembership, err = proof.NonMembership.MarshalJSON(); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(enc)
+}
+
+// UnmarshalQueryProof decodes a proof encoded by MarshalJSON into new elements
+func (c *DLPolyCommit) UnmarshalQueryProof(data []byte) (*QueryProof, error) {
+	var enc queryJSON
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return nil, err
+	}
+
+	proof := &QueryProof{Present: enc.Present, Absent: enc.Absent}
+	if enc.Membership != "" {
+		proof.Membership = c.NewG1()
+		if err := curve.SetHex(proof.Membership, enc.Membership); err != nil {
+			return nil, fmt.Errorf("membership: %v", err)
+		}
+	}
+	if len(enc.NonMembership) > 0 {
+		var err error
+		if proof.NonMembership, err = c.UnmarshalNonMembershipProof(enc.NonMembership); err != nil {
+			return nil, fmt.Errorf("non-membership: %v", err)
+		}
+	}
+
+	return proof, nil
+}
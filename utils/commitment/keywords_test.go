@@ -0,0 +1,111 @@
+package commitment
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDLPolyCommit_KeywordIndex(test *testing.T) {
+	c := new(DLPolyCommit)
+	const t = 8
+	c.SetupFix(t)
+
+	key, err := NewKeywordKey(nil)
+	assert.Nil(test, err, "NewKeywordKey")
+
+	keywords := []string{"alice", "bob", "invoice", "2021", "bob"}
+	idx, err := c.NewKeywordIndex(key, keywords)
+	assert.Nil(test, err, "NewKeywordIndex")
+	assert.Equal(test, 4, idx.poly.GetDegree(), "repeated keywords count once")
+	assert.True(test, idx.Contains("invoice"))
+	assert.False(test, idx.Contains("carol"))
+
+	C := idx.Commitment
+
+	// membership of one and of several keywords
+	for _, query := range [][]string{{"alice"}, {"bob", "2021", "alice"}} {
+		w := c.NewG1()
+		assert.Nil(test, idx.ProveMembership(w, query), "ProveMembership %v", query)
+		assert.True(test, c.VerifyMembership(C, key, query, w), "VerifyMembership %v", query)
+		assert.False(test, c.VerifyMembership(C, key, append(query, "carol"), w), "a keyword added")
+	}
+	assert.NotNil(test, idx.ProveMembership(c.NewG1(), []string{"alice", "carol"}), "carol is not a member")
+
+	w := c.NewG1()
+	idx.ProveMembership(w, []string{"alice"})
+	assert.False(test, c.VerifyMembership(C, key, []string{"bob"}, w), "witness of another keyword")
+
+	// non-membership of one and of several keywords
+	for _, query := range [][]string{{"carol"}, {"carol", "dave", "receipt"}} {
+		proof, err := idx.ProveNonMembership(query)
+		assert.Nil(test, err, "ProveNonMembership %v", query)
+		assert.True(test, c.VerifyNonMembership(C, key, query, proof), "VerifyNonMembership %v", query)
+		assert.False(test, c.VerifyNonMembership(C, key, append(query, "bob"), proof), "a member added")
+
+		data, err := json.Marshal(proof)
+		assert.Nil(test, err, "MarshalJSON")
+		decoded, err := c.UnmarshalNonMembershipProof(data)
+		assert.Nil(test, err, "UnmarshalNonMembershipProof")
+		assert.True(test, c.VerifyNonMembership(C, key, query, decoded), "decoded proof")
+	}
+	_, err = idx.ProveNonMembership([]string{"carol", "bob"})
+	assert.NotNil(test, err, "bob is a member")
+
+	// the proofs are bound to the commitment
+	other, _ := c.NewKeywordIndex(key, []string{"alice", "carol"})
+	proof, _ := idx.ProveNonMembership([]string{"carol"})
+	assert.False(test, c.VerifyNonMembership(other.Commitment, key, []string{"carol"}, proof), "other commitment")
+
+	// the empty set contains nothing
+	empty, err := c.NewKeywordIndex(key, nil)
+	assert.Nil(test, err, "empty set")
+	proof, err = empty.ProveNonMembership([]string{"alice"})
+	assert.Nil(test, err, "ProveNonMembership on the empty set")
+	assert.True(test, c.VerifyNonMembership(empty.Commitment, key, []string{"alice"}, proof))
+
+	_, err = c.NewKeywordIndex(key, []string{"1", "2", "3", "4", "5", "6", "7", "8", "9"})
+	assert.NotNil(test, err, "more keywords than the degree")
+
+	// without the key, the commitment can't be recomputed from the keywords, and the proofs don't verify
+	otherKey, _ := NewKeywordKey(nil)
+	guess, err := c.NewKeywordIndex(otherKey, keywords)
+	assert.Nil(test, err, "NewKeywordIndex")
+	assert.False(test, guess.Commitment.Equals(C), "commitment with another key")
+
+	w = c.NewG1()
+	idx.ProveMembership(w, []string{"alice"})
+	assert.False(test, c.VerifyMembership(C, otherKey, []string{"alice"}, w), "membership with another key")
+
+	_, err = c.NewKeywordIndex(key[:8], keywords)
+	assert.NotNil(test, err, "short key")
+
+	// a query mixing members and non-members is answered with both proofs
+	for _, query := range [][]string{{"alice"}, {"carol"}, {"bob", "carol", "alice", "dave", "bob"}} {
+		answer, err := idx.ProveQuery(query)
+		assert.Nil(test, err, "ProveQuery %v", query)
+		assert.True(test, c.VerifyQuery(C, key, query, answer), "VerifyQuery %v", query)
+
+		data, err := json.Marshal(answer)
+		assert.Nil(test, err, "MarshalJSON")
+		decoded, err := c.UnmarshalQueryProof(data)
+		assert.Nil(test, err, "UnmarshalQueryProof")
+		assert.True(test, c.VerifyQuery(C, key, query, decoded), "decoded answer %v", query)
+	}
+
+	_, err = idx.ProveQuery(nil)
+	assert.NotNil(test, err, "empty query")
+
+	answer, _ := idx.ProveQuery([]string{"bob", "carol"})
+	assert.Equal(test, []string{"bob"}, answer.Present)
+	assert.Equal(test, []string{"carol"}, answer.Absent)
+	assert.False(test, c.VerifyQuery(C, key, []string{"bob", "carol", "dave"}, answer), "a keyword unanswered")
+	assert.False(test, c.VerifyQuery(C, key, []string{"bob"}, answer), "a keyword not queried")
+
+	swapped := &QueryProof{Present: answer.Absent, Absent: answer.Present, Membership: answer.Membership, NonMembership: answer.NonMembership}
+	assert.False(test, c.VerifyQuery(C, key, []string{"bob", "carol"}, swapped), "members and non-members swapped")
+
+	twice := &QueryProof{Present: []string{"bob"}, Absent: []string{"carol", "bob"}, Membership: answer.Membership, NonMembership: answer.NonMembership}
+	assert.False(test, c.VerifyQuery(C, key, []string{"bob", "carol"}, twice), "a keyword answered twice")
+}
@@ -0,0 +1,59 @@
+package commitment
+
+import (
+	"fmt"
+
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/curve"
+	"github.com/nikamn/BC-SSE/utils/parallel"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+)
+
+// The parallel functions share the powers of the SRS and the polynomials between goroutines, which only read them,
+// and give each goroutine its own scratch elements. A DLPolyCommit can then be used from several goroutines
+// at once, as long as they do not write the same result elements: see the thread-safety notes of curve.Element.
+// w <= 0 means parallel.Workers goroutines
+
+// evalInExponentParallel is evalInExponent with the coefficients split into chunks on w goroutines.
+// Each chunk multiplies its powers into a partial product, and the partial products are multiplied into res
+func (c *DLPolyCommit) evalInExponentParallel(res curve.Element, newElement func() curve.Element, pk []curve.Element, poly polyring.Polynomial, w int) {
+	coeffs := poly.GetDegree() + 1
+	partials := make([]curve.Element, coeffs)
+
+	chunks := parallel.Chunks(coeffs, w, func(chunk, lo, hi int) {
+		partial, tmp := newElement(), newElement()
+		for i := lo; i < hi; i++ {
+			ci, err := poly.GetCoefficient(i)
+			if err != nil {
+				panic("can't get coeff i")
+			}
+			tmp.PowBig(pk[i], c.exponent(&ci))
+			partial.Mul(partial, tmp)
+		}
+		partials[chunk] = partial
+	})
+
+	res.Set1()
+	for _, partial := range partials[:chunks] {
+		res.Mul(res, partial)
+	}
+}
+
+// CommitParallel sets res to g^polyring(alpha) as Commit, with the exponentiations on w goroutines
+func (c *DLPolyCommit) CommitParallel(res curve.Element, poly polyring.Polynomial, w int) {
+	c.evalInExponentParallel(res, c.NewG1, c.pk, poly, w)
+}
+
+// CreateWitnesses sets res[i] to the witness of polynomial at xs[i] as CreateWitness, with the witnesses
+// computed on w goroutines. Each witness is computed serially, so that there is one level of parallelism
+func (c *DLPolyCommit) CreateWitnesses(res []curve.Element, polynomial polyring.Polynomial, xs []*gmp.Int, w int) error {
+	if len(res) != len(xs) {
+		return fmt.Errorf("%d results for %d points", len(res), len(xs))
+	}
+
+	parallel.For(len(xs), w, func(i int) {
+		c.CreateWitness(res[i], polynomial, xs[i])
+	})
+
+	return nil
+}
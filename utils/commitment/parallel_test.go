@@ -0,0 +1,96 @@
+package commitment
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/curve"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDLPolyCommit_Parallel(test *testing.T) {
+	c := new(DLPolyCommit)
+	const t = 10
+	rnd := rand.New(rand.NewSource(99))
+	c.SetupFix(t)
+
+	for _, degree := range []int{0, 3, t} {
+		poly, err := polyring.NewRand(degree, rnd, c.p)
+		assert.Nil(test, err, "NewRand")
+
+		C := c.NewG1()
+		c.Commit(C, poly)
+
+		xs := make([]*gmp.Int, 7)
+		for i := range xs {
+			xs[i] = gmp.NewInt(int64(i))
+		}
+
+		for _, w := range []int{0, 1, 3, 50} {
+			parallelC := c.NewG1()
+			c.CommitParallel(parallelC, poly, w)
+			assert.True(test, C.Equals(parallelC), "CommitParallel degree %d, workers %d", degree, w)
+
+			witnesses := make([]curve.Element, len(xs))
+			for i := range witnesses {
+				witnesses[i] = c.NewG1()
+			}
+			assert.Nil(test, c.CreateWitnesses(witnesses, poly, xs, w), "CreateWitnesses")
+
+			for i, x := range xs {
+				expected := c.NewG1()
+				c.CreateWitness(expected, poly, x)
+				assert.True(test, expected.Equals(witnesses[i]), "witness %d, workers %d", i, w)
+			}
+		}
+	}
+
+	assert.NotNil(test, c.CreateWitnesses(make([]curve.Element, 2), polyring.NewOne(), []*gmp.Int{gmp.NewInt(1)}, 0), "length mismatch")
+}
+
+// BenchmarkDLPolyCommit_Parallel compares Commit and CreateWitness with their parallel versions on parallel.Workers
+// goroutines. Run it with -cpu 1,2,4,... to see the speedup
+func BenchmarkDLPolyCommit_Parallel(b *testing.B) {
+	c := new(DLPolyCommit)
+	const t = 64
+	rnd := rand.New(rand.NewSource(99))
+	c.SetupFix(t)
+
+	poly, _ := polyring.NewRand(t, rnd, c.p)
+	xs := make([]*gmp.Int, 16)
+	witnesses := make([]curve.Element, len(xs))
+	for i := range xs {
+		xs[i] = gmp.NewInt(int64(i))
+		witnesses[i] = c.NewG1()
+	}
+	C := c.NewG1()
+
+	b.Run(fmt.Sprintf("Commit/%d", t), func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			c.Commit(C, poly)
+		}
+	})
+
+	b.Run(fmt.Sprintf("CommitParallel/%d", t), func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			c.CommitParallel(C, poly, 0)
+		}
+	})
+
+	b.Run(fmt.Sprintf("CreateWitness/%d", len(xs)), func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j, x := range xs {
+				c.CreateWitness(witnesses[j], poly, x)
+			}
+		}
+	})
+
+	b.Run(fmt.Sprintf("CreateWitnesses/%d", len(xs)), func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			c.CreateWitnesses(witnesses, poly, xs, 0)
+		}
+	})
+}
@@ -0,0 +1,331 @@
+package commitment
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+
+	"github.com/nikamn/BC-SSE/utils/conv"
+	"github.com/nikamn/BC-SSE/utils/curve"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+)
+
+// srsMagic and srsVersion prefix a serialized SRS
+var srsMagic = []byte("KZGSRS")
+
+// Version 1 has no hiding powers, versions 1 and 2 are for the symmetric pairing of ecparam PBC256
+const srsVersion = 3
+
+// MaxSRSDegree is the highest degree of an SRS that ReadSRS accepts, so that a corrupt number of powers
+// can't make it allocate gigabytes before reading any power
+const MaxSRSDegree = 1 << 20
+
+// SRS is the structured reference string of DLPolyCommit: g, g^s, g^{s^2}, ..., g^{s^degree} in G1,
+// g2, g2^s, ..., g2^{s^degree} in G2, and h, h^s, ..., h^{s^degree} in G1 for hiding commitments
+// The trapdoor s must be unknown to everyone, otherwise evaluation proofs can be forged
+type SRS struct {
+	curve curve.Curve
+	pk    []curve.Element
+	pk2   []curve.Element // the same as pk if the pairing is symmetric
+	hk    []curve.Element // nil in an SRS read from version 1
+}
+
+// NewSRS samples the trapdoor s from rnd, computes the powers g^{s^i} on Curve and erases s.
+// rnd defaults to crypto/rand.Reader if nil
+func NewSRS(degree int, rnd io.Reader) (*SRS, error) {
+	if degree < 1 || degree > MaxSRSDegree {
+		return nil, fmt.Errorf("degree must be in [1, %d], got %d", MaxSRSDegree, degree)
+	}
+
+	s, err := randomScalar(Curve, rnd)
+	if err != nil {
+		return nil, err
+	}
+	defer erase(s)
+
+	return newSRS(Curve, degree, s), nil
+}
+
+// newSRS returns the SRS of trapdoor s
+func newSRS(cv curve.Curve, degree int, s *big.Int) *SRS {
+	srs := &SRS{
+		curve: cv,
+		pk:    make([]curve.Element, degree+1),
+		hk:    make([]curve.Element, degree+1),
+	}
+	srs.pk2 = srs.pk
+	if !cv.Symmetric() {
+		srs.pk2 = make([]curve.Element, degree+1)
+	}
+
+	g, g2, h := cv.G1(), cv.G2(), hidingGenerator(cv)
+
+	// tmp = s^i
+	tmp := big.NewInt(1)
+	defer erase(tmp)
+	order := cv.Order()
+	for i := 0; i <= degree; i++ {
+		srs.pk[i] = cv.NewG1().PowBig(g, tmp)
+		srs.hk[i] = cv.NewG1().PowBig(h, tmp)
+		if !cv.Symmetric() {
+			srs.pk2[i] = cv.NewG2().PowBig(g2, tmp)
+		}
+
+		tmp.Mul(tmp, s)
+		tmp.Mod(tmp, order)
+	}
+
+	return srs
+}
+
+// erase overwrites the words of a secret big integer
+func erase(x *big.Int) {
+	words := x.Bits()
+	for i := range words {
+		words[i] = 0
+	}
+	x.SetInt64(0)
+}
+
+// GetCurve returns the curve of the SRS
+func (srs *SRS) GetCurve() curve.Curve {
+	return srs.curve
+}
+
+// GetDegree returns the maximum degree of polynomials that can be committed to
+func (srs *SRS) GetDegree() int {
+	return len(srs.pk) - 1
+}
+
+// GetPower returns g^{s^i}
+func (srs *SRS) GetPower(i int) curve.Element {
+	return srs.pk[i]
+}
+
+// GetPower2 returns g2^{s^i}
+func (srs *SRS) GetPower2(i int) curve.Element {
+	return srs.pk2[i]
+}
+
+// GetHidingPower returns h^{s^i}, or nil if the SRS has no hiding powers
+func (srs *SRS) GetHidingPower(i int) curve.Element {
+	if srs.hk == nil {
+		return nil
+	}
+	return srs.hk[i]
+}
+
+// WriteTo writes the SRS as magic, version, curve name length and name, number of powers, G1 and G2 element lengths
+// and number of hiding powers (0 or the number of powers), followed by the compressed powers in G1,
+// the powers in G2 unless the pairing is symmetric, and the hiding powers
+func (srs *SRS) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+
+	buf.Write(srsMagic)
+	buf.WriteByte(srsVersion)
+
+	name := srs.curve.Name()
+	buf.WriteByte(byte(len(name)))
+	buf.WriteString(name)
+
+	binary.Write(&buf, binary.BigEndian, uint32(len(srs.pk)))
+	binary.Write(&buf, binary.BigEndian, uint32(srs.curve.G1Length()))
+	binary.Write(&buf, binary.BigEndian, uint32(srs.curve.G2Length()))
+	binary.Write(&buf, binary.BigEndian, uint32(len(srs.hk)))
+
+	for i := range srs.pk {
+		buf.Write(srs.pk[i].Bytes())
+	}
+	if !srs.curve.Symmetric() {
+		for i := range srs.pk2 {
+			buf.Write(srs.pk2[i].Bytes())
+		}
+	}
+	for i := range srs.hk {
+		buf.Write(srs.hk[i].Bytes())
+	}
+
+	return buf.WriteTo(w)
+}
+
+// ReadSRS reads an SRS on Curve written by WriteTo, or by a version without curve name or hiding powers
+func ReadSRS(r io.Reader) (*SRS, error) {
+	header := make([]byte, len(srsMagic)+1)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(header[:len(srsMagic)], srsMagic) {
+		return nil, errors.New("not an SRS")
+	}
+
+	version := header[len(srsMagic)]
+	if version < 1 || version > srsVersion {
+		return nil, fmt.Errorf("unsupported SRS version %d", version)
+	}
+
+	if version >= 3 {
+		var nameLen [1]byte
+		if _, err := io.ReadFull(r, nameLen[:]); err != nil {
+			return nil, err
+		}
+		name := make([]byte, nameLen[0])
+		if _, err := io.ReadFull(r, name); err != nil {
+			return nil, err
+		}
+		if string(name) != Curve.Name() {
+			return nil, fmt.Errorf("SRS is for curve %s, not %s", name, Curve.Name())
+		}
+	} else if !Curve.Symmetric() {
+		return nil, errors.New("SRS is for another curve")
+	}
+
+	var count, elementLen, element2Len, hidingCount uint32
+	if err := binary.Read(r, binary.BigEndian, &count); err != nil {
+		return nil, err
+	}
+	if err := binary.Read(r, binary.BigEndian, &elementLen); err != nil {
+		return nil, err
+	}
+	element2Len = elementLen
+	if version >= 3 {
+		if err := binary.Read(r, binary.BigEndian, &element2Len); err != nil {
+			return nil, err
+		}
+	}
+	if version >= 2 {
+		if err := binary.Read(r, binary.BigEndian, &hidingCount); err != nil {
+			return nil, err
+		}
+	}
+
+	if count < 2 {
+		return nil, errors.New("SRS too short")
+	}
+
+	if count > MaxSRSDegree+1 {
+		return nil, fmt.Errorf("SRS degree %d is above the maximum %d", count-1, MaxSRSDegree)
+	}
+
+	if elementLen != uint32(Curve.G1Length()) || element2Len != uint32(Curve.G2Length()) {
+		return nil, errors.New("SRS is for another curve")
+	}
+
+	if hidingCount != 0 && hidingCount != count {
+		return nil, errors.New("number of hiding powers mismatch")
+	}
+
+	srs := &SRS{curve: Curve, pk: make([]curve.Element, count)}
+	srs.pk2 = srs.pk
+	if !Curve.Symmetric() {
+		srs.pk2 = make([]curve.Element, count)
+	}
+	if hidingCount != 0 {
+		srs.hk = make([]curve.Element, hidingCount)
+	}
+
+	// the powers in G2 are read in the order they were written, or alias the ones in G1
+	groups := [][]curve.Element{srs.pk, srs.hk}
+	newElement := []func() curve.Element{Curve.NewG1, Curve.NewG1}
+	lengths := []uint32{elementLen, elementLen}
+	if !Curve.Symmetric() {
+		groups = [][]curve.Element{srs.pk, srs.pk2, srs.hk}
+		newElement = []func() curve.Element{Curve.NewG1, Curve.NewG2, Curve.NewG1}
+		lengths = []uint32{elementLen, element2Len, elementLen}
+	}
+
+	for k, powers := range groups {
+		buf := make([]byte, lengths[k])
+		for i := range powers {
+			powers[i] = newElement[k]()
+			if _, err := io.ReadFull(r, buf); err != nil {
+				return nil, err
+			}
+
+			if err := powers[i].SetBytes(buf); err != nil {
+				return nil, fmt.Errorf("power %d: %v", i, err)
+			}
+
+			if powers[i].Is1() {
+				return nil, fmt.Errorf("power %d is the identity", i)
+			}
+		}
+	}
+
+	return srs, nil
+}
+
+// SaveSRS writes the SRS to the file at path
+func SaveSRS(path string, srs *SRS) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = srs.WriteTo(f)
+
+	return err
+}
+
+// LoadSRS reads the SRS from the file at path
+func LoadSRS(path string) (*SRS, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadSRS(bufio.NewReader(f))
+}
+
+// SetupSRS initializes the commitment scheme from an SRS
+func (c *DLPolyCommit) SetupSRS(srs *SRS) {
+	c.degree = srs.GetDegree()
+
+	c.curve = srs.curve
+	c.p = conv.BigInt2GmpInt(srs.curve.Order())
+	field, err := polyring.GetField(c.p)
+	if err != nil {
+		panic("the order of the curve is not prime")
+	}
+	c.field = field
+
+	// the powers of g and h are the bases of every commitment and witness, so they get the tables of PreparePower.
+	// They are copies, so that the SRS is left as it is
+	c.pk = preparePowers(srs.curve, srs.pk)
+	c.pk2 = srs.pk2
+	if srs.curve.Symmetric() {
+		c.pk2 = c.pk
+	}
+	c.hk = nil
+	if srs.hk != nil {
+		c.hk = preparePowers(srs.curve, srs.hk)
+	}
+}
+
+// preparePowers returns copies of the powers in G1 with PreparePower applied
+func preparePowers(cv curve.Curve, powers []curve.Element) []curve.Element {
+	prepared := make([]curve.Element, len(powers))
+	for i := range powers {
+		prepared[i] = cv.NewG1().Set(powers[i]).PreparePower()
+	}
+	return prepared
+}
+
+// LoadSetup initializes the commitment scheme from the SRS file at path
+func (c *DLPolyCommit) LoadSetup(path string) error {
+	srs, err := LoadSRS(path)
+	if err != nil {
+		return err
+	}
+
+	c.SetupSRS(srs)
+
+	return nil
+}
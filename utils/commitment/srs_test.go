@@ -0,0 +1,68 @@
+package commitment
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSRS(test *testing.T) {
+	const t = 5
+	rnd := rand.New(rand.NewSource(99))
+
+	srs, err := NewSRS(t, nil)
+	assert.Nil(test, err, "NewSRS")
+	assert.Equal(test, t, srs.GetDegree())
+	assert.True(test, srs.GetPower(0).Equals(Curve.G1()), "g")
+
+	_, err = NewSRS(0, nil)
+	assert.NotNil(test, err, "degree 0")
+
+	// round trip through the binary encoding
+	var buf bytes.Buffer
+	_, err = srs.WriteTo(&buf)
+	assert.Nil(test, err, "WriteTo")
+
+	decoded, err := ReadSRS(bytes.NewReader(buf.Bytes()))
+	assert.Nil(test, err, "ReadSRS")
+	for i := 0; i <= t; i++ {
+		assert.True(test, srs.GetPower(i).Equals(decoded.GetPower(i)), "power %d", i)
+	}
+
+	_, err = ReadSRS(bytes.NewReader(buf.Bytes()[:buf.Len()-1]))
+	assert.NotNil(test, err, "truncated")
+
+	// a corrupt number of powers is rejected before allocating the powers
+	corrupt := append([]byte(nil), buf.Bytes()...)
+	countAt := len(srsMagic) + 2 + len(Curve.Name())
+	copy(corrupt[countAt:], []byte{0xff, 0xff, 0xff, 0xff})
+	_, err = ReadSRS(bytes.NewReader(corrupt))
+	assert.NotNil(test, err, "number of powers above MaxSRSDegree")
+
+	_, err = NewSRS(MaxSRSDegree+1, nil)
+	assert.NotNil(test, err, "degree above MaxSRSDegree")
+
+	// commit and open with a random setup
+	c := new(DLPolyCommit)
+	c.SetupSRS(decoded)
+
+	poly, err := polyring.NewRand(t, rnd, c.p)
+	assert.Nil(test, err, "NewRand")
+
+	x := gmp.NewInt(0)
+	x.Rand(rnd, c.p)
+	polyOfX := gmp.NewInt(0)
+
+	C := c.NewG1()
+	w := c.NewG1()
+	c.Commit(C, poly)
+	c.PolyEval(polyOfX, poly, x)
+	c.CreateWitness(w, poly, x)
+
+	assert.True(test, c.VerifyPoly(C, poly), "VerifyPoly")
+	assert.True(test, c.VerifyEval(C, x, polyOfX, w), "VerifyEval")
+}
@@ -0,0 +1,310 @@
+// Package ctfield implements constant-time arithmetic in the scalar field Z_r of ecparam.PBC256,
+// r = 2^255 + 2^41 + 1, for the secret coefficients and shares that gmp would leak through timing.
+// Elements are four 64-bit limbs in Montgomery form a R mod r, R = 2^256. The running time of the
+// operations only depends on public values, such as the degrees of polynomials and the number of points.
+// Converting from and to gmp.Int at the boundaries is not constant time, and neither are the exponentiations of
+// utils/commitment, so a secret is only protected while it stays in ctfield
+package ctfield
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/big"
+	"math/bits"
+
+	"github.com/ncw/gmp"
+)
+
+// Limbs is the number of 64-bit limbs of an Element
+const Limbs = 4
+
+// Bytes is the length of the encoding of an Element
+const Bytes = 8 * Limbs
+
+// Element is an element of Z_r in Montgomery form, the zero value is 0.
+// As with gmp.Int, the methods set the receiver to the result and return it
+type Element [Limbs]uint64
+
+// r in little endian limbs
+var modulus = Element{0x0000020000000001, 0, 0, 0x8000000000000000}
+
+// qInvNeg = -1/r mod 2^64
+const qInvNeg = 0x000001ffffffffff
+
+// rSquare = R^2 mod r, to convert to the Montgomery form
+var rSquare = Element{0x0000100000000004, 0x0000000000100000, 0, 0}
+
+// one = R mod r, 1 in Montgomery form
+var one = Element{0xfffffdffffffffff, 0xffffffffffffffff, 0xffffffffffffffff, 0x7fffffffffffffff}
+
+// rMinus2 is the exponent of the inverse by Fermat's little theorem
+var rMinus2 = Element{0x000001ffffffffff, 0, 0, 0x8000000000000000}
+
+// Modulus returns r
+func Modulus() *gmp.Int {
+	return new(gmp.Int).SetBytes(limbsToBytes(modulus))
+}
+
+// limbsToBytes returns the limbs in big endian
+func limbsToBytes(a Element) []byte {
+	buf := make([]byte, Bytes)
+	for i := 0; i < Limbs; i++ {
+		binary.BigEndian.PutUint64(buf[Bytes-8*(i+1):], a[i])
+	}
+	return buf
+}
+
+// reduce subtracts r from (hi, a) if it is at least r, hi being a carry word. (hi, a) must be less than 2r
+func reduce(a *Element, hi uint64) {
+	var s Element
+	var b uint64
+	s[0], b = bits.Sub64(a[0], modulus[0], 0)
+	s[1], b = bits.Sub64(a[1], modulus[1], b)
+	s[2], b = bits.Sub64(a[2], modulus[2], b)
+	s[3], b = bits.Sub64(a[3], modulus[3], b)
+	_, b = bits.Sub64(hi, 0, b)
+
+	// b == 0 iff (hi, a) >= r, then take s
+	mask := b - 1
+	for i := range a {
+		a[i] = (s[i] & mask) | (a[i] &^ mask)
+	}
+}
+
+// SetZero sets z to 0
+func (z *Element) SetZero() *Element {
+	*z = Element{}
+	return z
+}
+
+// SetOne sets z to 1
+func (z *Element) SetOne() *Element {
+	*z = one
+	return z
+}
+
+// Set sets z to x
+func (z *Element) Set(x *Element) *Element {
+	*z = *x
+	return z
+}
+
+// SetUint64 sets z to v mod r
+func (z *Element) SetUint64(v uint64) *Element {
+	*z = Element{v}
+	return z.Mul(z, &rSquare)
+}
+
+// SetGmp sets z to x mod r. The reduction is done by gmp, so x is not protected
+func (z *Element) SetGmp(x *gmp.Int) *Element {
+	reduced := new(gmp.Int).Mod(x, Modulus())
+	z.setCanonicalBytes(reduced.Bytes())
+	return z
+}
+
+// SetBig sets z to x mod r. The reduction is done by math/big, so x is not protected
+func (z *Element) SetBig(x *big.Int) *Element {
+	reduced := new(big.Int).Mod(x, new(big.Int).SetBytes(limbsToBytes(modulus)))
+	z.setCanonicalBytes(reduced.Bytes())
+	return z
+}
+
+// setCanonicalBytes sets z to the big endian buf, which must be less than r
+func (z *Element) setCanonicalBytes(buf []byte) {
+	padded := make([]byte, Bytes)
+	copy(padded[Bytes-len(buf):], buf)
+	for i := 0; i < Limbs; i++ {
+		z[i] = binary.BigEndian.Uint64(padded[Bytes-8*(i+1):])
+	}
+	z.Mul(z, &rSquare)
+}
+
+// SetBytes sets z to the big endian buf of Bytes bytes, which must encode a value less than r
+func (z *Element) SetBytes(buf []byte) error {
+	if len(buf) != Bytes {
+		return errors.New("wrong length")
+	}
+
+	var a Element
+	for i := 0; i < Limbs; i++ {
+		a[i] = binary.BigEndian.Uint64(buf[Bytes-8*(i+1):])
+	}
+	if !a.lessThanModulus() {
+		return errors.New("not reduced mod r")
+	}
+
+	*z = a
+	z.Mul(z, &rSquare)
+	return nil
+}
+
+// lessThanModulus returns if the limbs of a, not in Montgomery form, are less than r
+func (a *Element) lessThanModulus() bool {
+	var b uint64
+	_, b = bits.Sub64(a[0], modulus[0], 0)
+	_, b = bits.Sub64(a[1], modulus[1], b)
+	_, b = bits.Sub64(a[2], modulus[2], b)
+	_, b = bits.Sub64(a[3], modulus[3], b)
+	return b == 1
+}
+
+// Bytes returns z in big endian, Bytes bytes long
+func (z *Element) Bytes() []byte {
+	var a Element
+	a.fromMont(z)
+	return limbsToBytes(a)
+}
+
+// Gmp returns z as a gmp.Int in [0, r)
+func (z *Element) Gmp() *gmp.Int {
+	return new(gmp.Int).SetBytes(z.Bytes())
+}
+
+// String returns z in decimal
+func (z *Element) String() string {
+	return z.Gmp().String()
+}
+
+// SetRandom sets z to a uniformly random element read from rnd, crypto/rand if nil
+func (z *Element) SetRandom(rnd io.Reader) (*Element, error) {
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+
+	buf := make([]byte, Bytes)
+	for {
+		if _, err := io.ReadFull(rnd, buf); err != nil {
+			return nil, err
+		}
+
+		// rejection sampling only leaks the rejected values, about one in two
+		if err := z.SetBytes(buf); err == nil {
+			return z, nil
+		}
+	}
+}
+
+// fromMont sets z to x / R mod r, the limbs of the value of x
+func (z *Element) fromMont(x *Element) {
+	z.Mul(x, &Element{1})
+}
+
+// Add sets z to x + y mod r
+func (z *Element) Add(x, y *Element) *Element {
+	var c uint64
+	z[0], c = bits.Add64(x[0], y[0], 0)
+	z[1], c = bits.Add64(x[1], y[1], c)
+	z[2], c = bits.Add64(x[2], y[2], c)
+	z[3], c = bits.Add64(x[3], y[3], c)
+
+	reduce(z, c)
+	return z
+}
+
+// Sub sets z to x - y mod r
+func (z *Element) Sub(x, y *Element) *Element {
+	var b uint64
+	z[0], b = bits.Sub64(x[0], y[0], 0)
+	z[1], b = bits.Sub64(x[1], y[1], b)
+	z[2], b = bits.Sub64(x[2], y[2], b)
+	z[3], b = bits.Sub64(x[3], y[3], b)
+
+	// add r back if there was a borrow
+	mask := -b
+	var c uint64
+	z[0], c = bits.Add64(z[0], modulus[0]&mask, 0)
+	z[1], c = bits.Add64(z[1], modulus[1]&mask, c)
+	z[2], c = bits.Add64(z[2], modulus[2]&mask, c)
+	z[3], _ = bits.Add64(z[3], modulus[3]&mask, c)
+
+	return z
+}
+
+// Neg sets z to -x mod r
+func (z *Element) Neg(x *Element) *Element {
+	return z.Sub(&Element{}, x)
+}
+
+// Mul sets z to x * y mod r, by the CIOS Montgomery multiplication. r has its top bit set,
+// so the intermediate result needs two more words
+func (z *Element) Mul(x, y *Element) *Element {
+	var t [Limbs + 2]uint64
+	var c, carry, hi, lo uint64
+
+	for i := 0; i < Limbs; i++ {
+		// t += x * y[i]
+		c = 0
+		for j := 0; j < Limbs; j++ {
+			hi, lo = bits.Mul64(x[j], y[i])
+			lo, carry = bits.Add64(lo, t[j], 0)
+			hi += carry
+			lo, carry = bits.Add64(lo, c, 0)
+			hi += carry
+			t[j], c = lo, hi
+		}
+		t[Limbs], carry = bits.Add64(t[Limbs], c, 0)
+		t[Limbs+1] = carry
+
+		// t = (t + m r) / 2^64, with m such that t + m r = 0 mod 2^64
+		m := t[0] * qInvNeg
+		hi, lo = bits.Mul64(m, modulus[0])
+		_, carry = bits.Add64(lo, t[0], 0)
+		c = hi + carry
+		for j := 1; j < Limbs; j++ {
+			hi, lo = bits.Mul64(m, modulus[j])
+			lo, carry = bits.Add64(lo, t[j], 0)
+			hi += carry
+			lo, carry = bits.Add64(lo, c, 0)
+			hi += carry
+			t[j-1], c = lo, hi
+		}
+		t[Limbs-1], carry = bits.Add64(t[Limbs], c, 0)
+		t[Limbs] = t[Limbs+1] + carry
+	}
+
+	copy(z[:], t[:Limbs])
+	reduce(z, t[Limbs])
+	return z
+}
+
+// Square sets z to x^2 mod r
+func (z *Element) Square(x *Element) *Element {
+	return z.Mul(x, x)
+}
+
+// Inverse sets z to 1/x mod r, computed as x^(r-2), so 0 for x == 0
+func (z *Element) Inverse(x *Element) *Element {
+	base, acc := *x, one
+	for i := 0; i < Limbs; i++ {
+		for j := 0; j < 64; j++ {
+			if (rMinus2[i]>>uint(j))&1 == 1 {
+				acc.Mul(&acc, &base)
+			}
+			base.Square(&base)
+		}
+	}
+
+	*z = acc
+	return z
+}
+
+// Select sets z to x if c == 1 and to y if c == 0, in constant time
+func (z *Element) Select(c uint64, x, y *Element) *Element {
+	mask := -c
+	for i := range z {
+		z[i] = (x[i] & mask) | (y[i] &^ mask)
+	}
+	return z
+}
+
+// IsZero returns if z == 0, in constant time
+func (z *Element) IsZero() bool {
+	return z[0]|z[1]|z[2]|z[3] == 0
+}
+
+// Equal returns if z == x, in constant time
+func (z *Element) Equal(x *Element) bool {
+	return (z[0]^x[0])|(z[1]^x[1])|(z[2]^x[2])|(z[3]^x[3]) == 0
+}
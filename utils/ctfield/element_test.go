@@ -0,0 +1,120 @@
+package ctfield
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/stretchr/testify/assert"
+)
+
+var randomness = rand.New(rand.NewSource(99))
+
+// testValues returns edge cases and random values in [0, r)
+func testValues() []*gmp.Int {
+	r := Modulus()
+	values := []*gmp.Int{gmp.NewInt(0), gmp.NewInt(1), gmp.NewInt(2), new(gmp.Int).Sub(r, gmp.NewInt(1)), new(gmp.Int).Sub(r, gmp.NewInt(2))}
+
+	// values with the top limbs full
+	top := new(gmp.Int).Lsh(gmp.NewInt(1), 255)
+	values = append(values, top, new(gmp.Int).Sub(top, gmp.NewInt(1)))
+
+	for i := 0; i < 50; i++ {
+		x := gmp.NewInt(0)
+		x.Rand(randomness, r)
+		values = append(values, x)
+	}
+	return values
+}
+
+func TestModulus(t *testing.T) {
+	r := gmp.NewInt(0)
+	r.SetString("57896044618658097711785492504343953926634992332820282019728792006155588075521", 10)
+	assert.Equal(t, 0, Modulus().Cmp(r), "r of ecparam.PBC256")
+
+	var x Element
+	assert.Equal(t, "1", x.SetOne().String())
+	assert.Equal(t, "1", x.SetGmp(new(gmp.Int).Add(r, gmp.NewInt(1))).String(), "reduced mod r")
+	assert.Equal(t, "0", x.SetGmp(r).String())
+	assert.Equal(t, "0", x.SetGmp(new(gmp.Int).Neg(r)).String())
+}
+
+func TestElement_Arithmetic(t *testing.T) {
+	r := Modulus()
+	values := testValues()
+
+	for i, a := range values {
+		b := values[(i*7+3)%len(values)]
+
+		var x, y, z Element
+		x.SetGmp(a)
+		y.SetGmp(b)
+		assert.Equal(t, 0, x.Gmp().Cmp(a), "round trip %s", a)
+
+		expected := gmp.NewInt(0)
+		expected.Add(a, b)
+		expected.Mod(expected, r)
+		assert.Equal(t, 0, z.Add(&x, &y).Gmp().Cmp(expected), "Add %s %s", a, b)
+
+		expected.Sub(a, b)
+		expected.Mod(expected, r)
+		assert.Equal(t, 0, z.Sub(&x, &y).Gmp().Cmp(expected), "Sub %s %s", a, b)
+
+		expected.Neg(a)
+		expected.Mod(expected, r)
+		assert.Equal(t, 0, z.Neg(&x).Gmp().Cmp(expected), "Neg %s", a)
+
+		expected.Mul(a, b)
+		expected.Mod(expected, r)
+		assert.Equal(t, 0, z.Mul(&x, &y).Gmp().Cmp(expected), "Mul %s %s", a, b)
+
+		// the receiver may be an operand
+		z.Set(&x)
+		assert.Equal(t, 0, z.Mul(&z, &z).Gmp().Cmp(new(gmp.Int).Mod(new(gmp.Int).Mul(a, a), r)), "Square in place %s", a)
+
+		if a.CmpInt32(0) != 0 {
+			expected.ModInverse(a, r)
+			assert.Equal(t, 0, z.Inverse(&x).Gmp().Cmp(expected), "Inverse %s", a)
+		} else {
+			assert.True(t, z.Inverse(&x).IsZero(), "Inverse of 0")
+		}
+
+		var fromBytes Element
+		assert.Nil(t, fromBytes.SetBytes(x.Bytes()), "SetBytes")
+		assert.True(t, fromBytes.Equal(&x), "SetBytes %s", a)
+	}
+
+	var x, y Element
+	x.SetUint64(5)
+	y.SetUint64(7)
+	var z Element
+	assert.True(t, z.Select(1, &x, &y).Equal(&x), "Select 1")
+	assert.True(t, z.Select(0, &x, &y).Equal(&y), "Select 0")
+	assert.False(t, x.Equal(&y))
+
+	// r itself is not a canonical encoding
+	assert.NotNil(t, z.SetBytes(limbsToBytes(modulus)), "SetBytes of r")
+	assert.NotNil(t, z.SetBytes(make([]byte, Bytes-1)), "SetBytes too short")
+}
+
+func TestElement_SetRandom(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 20; i++ {
+		var x Element
+		_, err := x.SetRandom(randomness)
+		assert.Nil(t, err, "SetRandom")
+		assert.True(t, x.Gmp().Cmp(Modulus()) < 0, "in [0, r)")
+		seen[x.String()] = true
+	}
+	assert.Equal(t, 20, len(seen), "distinct")
+}
+
+func BenchmarkElement_Mul(b *testing.B) {
+	var x, y Element
+	x.SetRandom(randomness)
+	y.SetRandom(randomness)
+
+	for i := 0; i < b.N; i++ {
+		x.Mul(&x, &y)
+	}
+}
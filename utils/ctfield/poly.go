@@ -0,0 +1,254 @@
+package ctfield
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+)
+
+// Polynomial is a polynomial over Z_r with constant-time arithmetic, to use in place of polyring.Polynomial
+// for secret polynomials. Its degree is that of the allocation, public: unlike in polyring, the leading
+// coefficients may be zero, since trimming them would depend on their values
+type Polynomial struct {
+	coeff []Element // coefficients P(x) = coeff[0] + coeff[1] x + ... + coeff[degree] x^degree
+}
+
+// New returns a polynomial P(x) = 0 of the specified degree
+func New(degree int) (Polynomial, error) {
+	if degree < 0 {
+		return Polynomial{}, fmt.Errorf("degree must be non-negative, got %d", degree)
+	}
+
+	return Polynomial{make([]Element, degree+1)}, nil
+}
+
+// NewRand returns a random polynomial of the specified degree with coefficients read from rnd, crypto/rand if nil.
+// As in polyring.NewRand, the highest coefficient is not zero
+func NewRand(degree int, rnd io.Reader) (Polynomial, error) {
+	poly, err := New(degree)
+	if err != nil {
+		return Polynomial{}, err
+	}
+
+	for i := range poly.coeff {
+		if _, err := poly.coeff[i].SetRandom(rnd); err != nil {
+			return Polynomial{}, err
+		}
+	}
+
+	// only the rejected coefficients are leaked
+	for poly.coeff[degree].IsZero() {
+		if _, err := poly.coeff[degree].SetRandom(rnd); err != nil {
+			return Polynomial{}, err
+		}
+	}
+
+	return poly, nil
+}
+
+// FromPolyring returns poly mod r. The conversion is done by gmp, so poly is not protected
+func FromPolyring(poly polyring.Polynomial) Polynomial {
+	res, _ := New(poly.GetDegree())
+	for i := range res.coeff {
+		ci, _ := poly.GetCoefficient(i)
+		res.coeff[i].SetGmp(&ci)
+	}
+	return res
+}
+
+// ToPolyring returns the polynomial as a polyring.Polynomial, with coefficients in [0, r).
+// The result is no longer protected
+func (poly Polynomial) ToPolyring() polyring.Polynomial {
+	res, err := polyring.New(poly.GetDegree())
+	if err != nil {
+		panic(err.Error())
+	}
+	for i := range poly.coeff {
+		res.SetCoefficientBig(i, poly.coeff[i].Gmp())
+	}
+	return res
+}
+
+// GetDegree returns the degree of the allocation, which is public
+func (poly Polynomial) GetDegree() int {
+	return len(poly.coeff) - 1
+}
+
+// GetCoefficient returns coeff[i]
+func (poly Polynomial) GetCoefficient(i int) (Element, error) {
+	if i < 0 || i >= len(poly.coeff) {
+		return Element{}, errors.New("out of boundary")
+	}
+	return poly.coeff[i], nil
+}
+
+// DeepCopy returns a copy of poly
+func (poly Polynomial) DeepCopy() Polynomial {
+	return Polynomial{append([]Element(nil), poly.coeff...)}
+}
+
+// String returns the coefficients in decimal separated by ';', as polyring.Polynomial.String
+func (poly Polynomial) String() string {
+	return poly.ToPolyring().String()
+}
+
+// Add sets poly to op1 + op2
+func (poly *Polynomial) Add(op1 Polynomial, op2 Polynomial) {
+	res, _ := New(max(op1.GetDegree(), op2.GetDegree()))
+	for i := range res.coeff {
+		if i < len(op1.coeff) {
+			res.coeff[i].Add(&res.coeff[i], &op1.coeff[i])
+		}
+		if i < len(op2.coeff) {
+			res.coeff[i].Add(&res.coeff[i], &op2.coeff[i])
+		}
+	}
+	*poly = res
+}
+
+// Sub sets poly to op1 - op2
+func (poly *Polynomial) Sub(op1 Polynomial, op2 Polynomial) {
+	res, _ := New(max(op1.GetDegree(), op2.GetDegree()))
+	for i := range res.coeff {
+		if i < len(op1.coeff) {
+			res.coeff[i].Add(&res.coeff[i], &op1.coeff[i])
+		}
+		if i < len(op2.coeff) {
+			res.coeff[i].Sub(&res.coeff[i], &op2.coeff[i])
+		}
+	}
+	*poly = res
+}
+
+// Mul sets poly to op1 * op2, by schoolbook multiplication
+func (poly *Polynomial) Mul(op1 Polynomial, op2 Polynomial) {
+	res, _ := New(op1.GetDegree() + op2.GetDegree())
+	var tmp Element
+	for i := range op1.coeff {
+		for j := range op2.coeff {
+			tmp.Mul(&op1.coeff[i], &op2.coeff[j])
+			res.coeff[i+j].Add(&res.coeff[i+j], &tmp)
+		}
+	}
+	*poly = res
+}
+
+// MulScalar sets poly to op * k
+func (poly *Polynomial) MulScalar(op Polynomial, k *Element) {
+	res := op.DeepCopy()
+	for i := range res.coeff {
+		res.coeff[i].Mul(&res.coeff[i], k)
+	}
+	*poly = res
+}
+
+// AddMul sets poly to poly + op * k
+func (poly *Polynomial) AddMul(op Polynomial, k *Element) {
+	prod := Polynomial{}
+	prod.MulScalar(op, k)
+	poly.Add(*poly, prod)
+}
+
+// Eval sets res to poly(x) by Horner's rule
+func (poly Polynomial) Eval(res *Element, x *Element) {
+	var acc Element
+	for i := len(poly.coeff) - 1; i >= 0; i-- {
+		acc.Mul(&acc, x)
+		acc.Add(&acc, &poly.coeff[i])
+	}
+	*res = acc
+}
+
+// EvalArray sets results[i] to poly(x[i])
+func (poly Polynomial) EvalArray(x []Element, results []Element) {
+	for i := range x {
+		poly.Eval(&results[i], &x[i])
+	}
+}
+
+// LagrangeInterpolate returns the polynomial of degree len(x)-1 through the points (x[i], y[i]).
+// The x are public and must be distinct, the y are protected.
+// With Z the product of x - x[i], it is the sum of y[i] / Z'(x[i]) * Z(x) / (x - x[i])
+func LagrangeInterpolate(x []Element, y []Element) (Polynomial, error) {
+	if len(x) == 0 || len(x) != len(y) {
+		return Polynomial{}, errors.New("mismatch length")
+	}
+
+	// Z = (x - x[0]) ... (x - x[n-1])
+	vanishing, _ := New(0)
+	vanishing.coeff[0].SetOne()
+	for i := range x {
+		factor, _ := New(1)
+		factor.coeff[0].Neg(&x[i])
+		factor.coeff[1].SetOne()
+		vanishing.Mul(vanishing, factor)
+	}
+
+	res, _ := New(len(x) - 1)
+	var denominator, tmp Element
+	for i := range x {
+		// Z'(x[i]) = prod (x[i] - x[j]), j != i
+		denominator.SetOne()
+		for j := range x {
+			if j != i {
+				tmp.Sub(&x[i], &x[j])
+				denominator.Mul(&denominator, &tmp)
+			}
+		}
+		if denominator.IsZero() {
+			return Polynomial{}, errors.New("duplicate points")
+		}
+		denominator.Inverse(&denominator)
+		denominator.Mul(&denominator, &y[i])
+
+		res.AddMul(divideRoot(vanishing, &x[i]), &denominator)
+	}
+
+	return res, nil
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// divideRoot returns poly / (x - root) by synthetic division, root being a root of poly
+func divideRoot(poly Polynomial, root *Element) Polynomial {
+	deg := poly.GetDegree()
+	quot, _ := New(deg - 1)
+
+	var acc Element
+	for i := deg; i > 0; i-- {
+		acc.Mul(&acc, root)
+		acc.Add(&acc, &poly.coeff[i])
+		quot.coeff[i-1] = acc
+	}
+
+	return quot
+}
+
+// Interpolate is interpolation.LagrangeInterpolate mod r with LagrangeInterpolate, using the first degree+1 points.
+// Only the conversions of y from and to gmp.Int are not constant time
+func Interpolate(degree int, x []*gmp.Int, y []*gmp.Int) (polyring.Polynomial, error) {
+	if len(x) < degree+1 || len(y) < degree+1 {
+		return polyring.Polynomial{}, errors.New("not enough points")
+	}
+
+	xs, ys := make([]Element, degree+1), make([]Element, degree+1)
+	for i := range xs {
+		xs[i].SetGmp(x[i])
+		ys[i].SetGmp(y[i])
+	}
+
+	poly, err := LagrangeInterpolate(xs, ys)
+	if err != nil {
+		return polyring.Polynomial{}, err
+	}
+
+	return poly.ToPolyring(), nil
+}
@@ -0,0 +1,139 @@
+package ctfield
+
+import (
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/interpolation"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolynomial_Arithmetic(t *testing.T) {
+	r := Modulus()
+
+	a, err := polyring.NewRand(6, randomness, r)
+	assert.Nil(t, err, "NewRand")
+	b, err := polyring.NewRand(3, randomness, r)
+	assert.Nil(t, err, "NewRand")
+
+	ctA, ctB := FromPolyring(a), FromPolyring(b)
+	assert.True(t, a.IsSame(ctA.ToPolyring()), "round trip")
+
+	var res Polynomial
+	expected := polyring.NewEmpty()
+
+	res.Add(ctA, ctB)
+	expected.Add(a, b)
+	expected.Mod(r)
+	assert.True(t, expected.IsSame(res.ToPolyring()), "Add")
+
+	res.Sub(ctB, ctA)
+	expected.Sub(b, a)
+	expected.Mod(r)
+	assert.True(t, expected.IsSame(res.ToPolyring()), "Sub")
+
+	res.Mul(ctA, ctB)
+	expected.Mul(a, b)
+	expected.Mod(r)
+	assert.True(t, expected.IsSame(res.ToPolyring()), "Mul")
+
+	var k Element
+	k.SetUint64(3)
+	res = ctB.DeepCopy()
+	res.AddMul(ctA, &k)
+	expected.ResetTo(b)
+	expected.GrowCapTo(a.GetCap())
+	expected.AddMul(a, gmp.NewInt(3))
+	expected.Mod(r)
+	assert.True(t, expected.IsSame(res.ToPolyring()), "AddMul")
+
+	// evaluations agree with polyring
+	xs := make([]*gmp.Int, 5)
+	ys := make([]*gmp.Int, 5)
+	polyring.VecInit(xs)
+	polyring.VecRand(xs, r, randomness)
+	polyring.VecInit(ys)
+	a.EvalModArray(xs, r, ys)
+
+	ctXs := make([]Element, len(xs))
+	for i := range xs {
+		ctXs[i].SetGmp(xs[i])
+	}
+	ctYs := make([]Element, len(xs))
+	ctA.EvalArray(ctXs, ctYs)
+	for i := range ys {
+		assert.Equal(t, 0, ctYs[i].Gmp().Cmp(ys[i]), "Eval at %s", xs[i])
+	}
+}
+
+func TestNewRand(t *testing.T) {
+	poly, err := NewRand(5, randomness)
+	assert.Nil(t, err, "NewRand")
+	assert.Equal(t, 5, poly.GetDegree())
+	assert.Equal(t, 5, poly.ToPolyring().GetDegree(), "leading coefficient is not zero")
+
+	_, err = NewRand(-1, randomness)
+	assert.NotNil(t, err, "negative degree")
+
+	poly, err = NewRand(5, nil)
+	assert.Nil(t, err, "NewRand from crypto/rand")
+	assert.Equal(t, 5, poly.GetDegree())
+}
+
+func TestInterpolate(t *testing.T) {
+	r := Modulus()
+	const degree = 6
+
+	poly, err := polyring.NewRand(degree, randomness, r)
+	assert.Nil(t, err, "NewRand")
+
+	xs := make([]*gmp.Int, degree+1)
+	ys := make([]*gmp.Int, degree+1)
+	for i := range xs {
+		xs[i] = gmp.NewInt(int64(i))
+	}
+	polyring.VecInit(ys)
+	poly.EvalModArray(xs, r, ys)
+
+	res, err := Interpolate(degree, xs, ys)
+	assert.Nil(t, err, "Interpolate")
+	assert.True(t, poly.IsSame(res), "interpolated")
+
+	// same result as the gmp implementation
+	expected, err := interpolation.LagrangeInterpolate(degree, xs, ys, r)
+	assert.Nil(t, err, "LagrangeInterpolate")
+	assert.True(t, expected.IsSame(res), "same as interpolation.LagrangeInterpolate")
+
+	_, err = Interpolate(degree+1, xs, ys)
+	assert.NotNil(t, err, "not enough points")
+
+	xs[1] = gmp.NewInt(0)
+	_, err = Interpolate(degree, xs, ys)
+	assert.NotNil(t, err, "duplicate points")
+}
+
+func BenchmarkPolynomial_Eval(b *testing.B) {
+	r := Modulus()
+	poly, _ := polyring.NewRand(100, randomness, r)
+	ctPoly := FromPolyring(poly)
+
+	x := gmp.NewInt(0)
+	x.Rand(randomness, r)
+	var ctX Element
+	ctX.SetGmp(x)
+
+	b.Run("gmp", func(b *testing.B) {
+		res := gmp.NewInt(0)
+		for i := 0; i < b.N; i++ {
+			poly.EvalMod(x, r, res)
+		}
+	})
+
+	b.Run("ctfield", func(b *testing.B) {
+		var res Element
+		for i := 0; i < b.N; i++ {
+			ctPoly.Eval(&res, &ctX)
+		}
+	})
+}
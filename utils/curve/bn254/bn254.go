@@ -0,0 +1,381 @@
+package curvebn254
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/nikamn/BC-SSE/utils/curve"
+)
+
+// BN254 is the Barreto-Naehrig curve alt_bn128 of the EVM precompiles (EIP-196, EIP-197), in pure Go
+var BN254 = &Curve{}
+
+// hashDST is the domain separation tag of HashToG1
+var hashDST = []byte("BC-SSE_BN254G1_XMD:SHA-256_SVDW_RO_")
+
+// Curve is BN254, an asymmetric pairing over groups of 254-bit prime order.
+// It holds no state, so it can be shared by goroutines; the elements follow the thread-safety notes of curve.Element
+type Curve struct{}
+
+// g1 is an element of G1, g2 of G2 and gt of GT
+type g1 struct{ p bn254.G1Affine }
+type g2 struct{ p bn254.G2Affine }
+type gt struct{ v bn254.GT }
+
+func unwrapG1(x curve.Element) *bn254.G1Affine {
+	el, ok := x.(*g1)
+	if !ok {
+		panic(fmt.Sprintf("not a BN254 G1 element: %T", x))
+	}
+	return &el.p
+}
+
+func unwrapG2(x curve.Element) *bn254.G2Affine {
+	el, ok := x.(*g2)
+	if !ok {
+		panic(fmt.Sprintf("not a BN254 G2 element: %T", x))
+	}
+	return &el.p
+}
+
+func unwrapGT(x curve.Element) *bn254.GT {
+	el, ok := x.(*gt)
+	if !ok {
+		panic(fmt.Sprintf("not a BN254 GT element: %T", x))
+	}
+	return &el.v
+}
+
+// reduce returns e mod r, as gnark expects exponents in [0, r)
+func reduce(e *big.Int) *big.Int {
+	return new(big.Int).Mod(e, fr.Modulus())
+}
+
+// decodeString decodes the hex string printed by String
+func decodeString(s string, el curve.Element) error {
+	buf, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	return el.SetBytes(buf)
+}
+
+// Set sets the element to x
+func (el *g1) Set(x curve.Element) curve.Element {
+	el.p.Set(unwrapG1(x))
+	return el
+}
+
+// Set1 sets the element to the point at infinity
+func (el *g1) Set1() curve.Element {
+	el.p = bn254.G1Affine{}
+	return el
+}
+
+// Mul sets the element to x + y
+func (el *g1) Mul(x, y curve.Element) curve.Element {
+	el.p.Add(unwrapG1(x), unwrapG1(y))
+	return el
+}
+
+// Div sets the element to x - y
+func (el *g1) Div(x, y curve.Element) curve.Element {
+	el.p.Sub(unwrapG1(x), unwrapG1(y))
+	return el
+}
+
+// PowBig sets the element to e * x
+func (el *g1) PowBig(x curve.Element, e *big.Int) curve.Element {
+	el.p.ScalarMultiplication(unwrapG1(x), reduce(e))
+	return el
+}
+
+// PreparePower does nothing, gnark-crypto has no table for a single fixed base
+func (el *g1) PreparePower() curve.Element {
+	return el
+}
+
+// Equals returns if the element equals x
+func (el *g1) Equals(x curve.Element) bool {
+	return el.p.Equal(unwrapG1(x))
+}
+
+// Is1 returns if the element is the point at infinity
+func (el *g1) Is1() bool {
+	return el.p.IsInfinity()
+}
+
+// Bytes returns the 32-byte compressed point
+func (el *g1) Bytes() []byte {
+	b := el.p.Bytes()
+	return b[:]
+}
+
+// SetBytes sets the element to a compressed point, checking that it is in G1
+func (el *g1) SetBytes(buf []byte) error {
+	if len(buf) != bn254.SizeOfG1AffineCompressed {
+		return errors.New("invalid length")
+	}
+	_, err := el.p.SetBytes(buf)
+	return err
+}
+
+// String returns Bytes in hex
+func (el *g1) String() string {
+	return hex.EncodeToString(el.Bytes())
+}
+
+// SetString sets the element to the one printed by String
+func (el *g1) SetString(s string) error {
+	return decodeString(s, el)
+}
+
+// Set sets the element to x
+func (el *g2) Set(x curve.Element) curve.Element {
+	el.p.Set(unwrapG2(x))
+	return el
+}
+
+// Set1 sets the element to the point at infinity
+func (el *g2) Set1() curve.Element {
+	el.p = bn254.G2Affine{}
+	return el
+}
+
+// Mul sets the element to x + y
+func (el *g2) Mul(x, y curve.Element) curve.Element {
+	el.p.Add(unwrapG2(x), unwrapG2(y))
+	return el
+}
+
+// Div sets the element to x - y
+func (el *g2) Div(x, y curve.Element) curve.Element {
+	el.p.Sub(unwrapG2(x), unwrapG2(y))
+	return el
+}
+
+// PowBig sets the element to e * x
+func (el *g2) PowBig(x curve.Element, e *big.Int) curve.Element {
+	el.p.ScalarMultiplication(unwrapG2(x), reduce(e))
+	return el
+}
+
+// PreparePower does nothing, gnark-crypto has no table for a single fixed base
+func (el *g2) PreparePower() curve.Element {
+	return el
+}
+
+// Equals returns if the element equals x
+func (el *g2) Equals(x curve.Element) bool {
+	return el.p.Equal(unwrapG2(x))
+}
+
+// Is1 returns if the element is the point at infinity
+func (el *g2) Is1() bool {
+	return el.p.IsInfinity()
+}
+
+// Bytes returns the 64-byte compressed point
+func (el *g2) Bytes() []byte {
+	b := el.p.Bytes()
+	return b[:]
+}
+
+// SetBytes sets the element to a compressed point, checking that it is in G2
+func (el *g2) SetBytes(buf []byte) error {
+	if len(buf) != bn254.SizeOfG2AffineCompressed {
+		return errors.New("invalid length")
+	}
+	_, err := el.p.SetBytes(buf)
+	return err
+}
+
+// String returns Bytes in hex
+func (el *g2) String() string {
+	return hex.EncodeToString(el.Bytes())
+}
+
+// SetString sets the element to the one printed by String
+func (el *g2) SetString(s string) error {
+	return decodeString(s, el)
+}
+
+// Set sets the element to x
+func (el *gt) Set(x curve.Element) curve.Element {
+	el.v.Set(unwrapGT(x))
+	return el
+}
+
+// Set1 sets the element to 1
+func (el *gt) Set1() curve.Element {
+	el.v.SetOne()
+	return el
+}
+
+// Mul sets the element to x * y
+func (el *gt) Mul(x, y curve.Element) curve.Element {
+	el.v.Mul(unwrapGT(x), unwrapGT(y))
+	return el
+}
+
+// Div sets the element to x / y
+func (el *gt) Div(x, y curve.Element) curve.Element {
+	var inv bn254.GT
+	inv.Inverse(unwrapGT(y))
+	el.v.Mul(unwrapGT(x), &inv)
+	return el
+}
+
+// PowBig sets the element to x^e
+func (el *gt) PowBig(x curve.Element, e *big.Int) curve.Element {
+	el.v.Exp(unwrapGT(x), *reduce(e))
+	return el
+}
+
+// PreparePower does nothing, gnark-crypto has no table for a single fixed base
+func (el *gt) PreparePower() curve.Element {
+	return el
+}
+
+// Equals returns if the element equals x
+func (el *gt) Equals(x curve.Element) bool {
+	return el.v.Equal(unwrapGT(x))
+}
+
+// Is1 returns if the element is 1
+func (el *gt) Is1() bool {
+	var one bn254.GT
+	return el.v.Equal(one.SetOne())
+}
+
+// Bytes returns the 384-byte element
+func (el *gt) Bytes() []byte {
+	b := el.v.Bytes()
+	return b[:]
+}
+
+// SetBytes sets the element to the one encoded by Bytes
+func (el *gt) SetBytes(buf []byte) error {
+	return el.v.SetBytes(buf)
+}
+
+// String returns Bytes in hex
+func (el *gt) String() string {
+	return hex.EncodeToString(el.Bytes())
+}
+
+// SetString sets the element to the one printed by String
+func (el *gt) SetString(s string) error {
+	return decodeString(s, el)
+}
+
+// Name identifies the curve in serialized data
+func (c *Curve) Name() string {
+	return "bn254"
+}
+
+// Order returns the prime order r of the groups
+func (c *Curve) Order() *big.Int {
+	return fr.Modulus()
+}
+
+// Symmetric returns false, BN254 has distinct G1 and G2
+func (c *Curve) Symmetric() bool {
+	return false
+}
+
+// NewG1 returns the point at infinity of G1
+func (c *Curve) NewG1() curve.Element {
+	return &g1{}
+}
+
+// NewG2 returns the point at infinity of G2
+func (c *Curve) NewG2() curve.Element {
+	return &g2{}
+}
+
+// NewGT returns 1 in GT
+func (c *Curve) NewGT() curve.Element {
+	return new(gt).Set1()
+}
+
+// G1 returns the generator (1, 2) of G1
+func (c *Curve) G1() curve.Element {
+	_, _, g1Aff, _ := bn254.Generators()
+	return &g1{p: g1Aff}
+}
+
+// G2 returns the generator of G2 used by the EVM
+func (c *Curve) G2() curve.Element {
+	_, _, _, g2Aff := bn254.Generators()
+	return &g2{p: g2Aff}
+}
+
+// HashToG1 maps msg to G1 with the SVDW hash to the curve of the IETF hash-to-curve draft
+func (c *Curve) HashToG1(msg []byte) curve.Element {
+	p, err := bn254.HashToCurveG1Svdw(msg, hashDST)
+	if err != nil {
+		panic(err.Error())
+	}
+	return &g1{p: p}
+}
+
+// Pair returns e(a, b)
+func (c *Curve) Pair(a, b curve.Element) curve.Element {
+	v, err := bn254.Pair([]bn254.G1Affine{*unwrapG1(a)}, []bn254.G2Affine{*unwrapG2(b)})
+	if err != nil {
+		panic(err.Error())
+	}
+	return &gt{v: v}
+}
+
+// G1Length returns the length of a compressed element of G1
+func (c *Curve) G1Length() int {
+	return bn254.SizeOfG1AffineCompressed
+}
+
+// G2Length returns the length of a compressed element of G2
+func (c *Curve) G2Length() int {
+	return bn254.SizeOfG2AffineCompressed
+}
+
+// EVMBytes returns an element of G1 or G2 in the uncompressed form of the EVM precompiles:
+// x || y for G1, and x.A1 || x.A0 || y.A1 || y.A0 for G2, with zeros for the point at infinity
+func EVMBytes(x curve.Element) []byte {
+	switch el := x.(type) {
+	case *g1:
+		if el.p.IsInfinity() {
+			return make([]byte, bn254.SizeOfG1AffineUncompressed)
+		}
+		b := el.p.RawBytes()
+		return b[:]
+	case *g2:
+		if el.p.IsInfinity() {
+			return make([]byte, bn254.SizeOfG2AffineUncompressed)
+		}
+		b := el.p.RawBytes()
+		return b[:]
+	default:
+		panic(fmt.Sprintf("not a BN254 point: %T", x))
+	}
+}
+
+// EVMPairingInput returns the input of the ecPairing precompile (address 0x08),
+// which returns 1 iff prod e(a[i], b[i]) == 1
+func EVMPairingInput(a, b []curve.Element) []byte {
+	if len(a) != len(b) {
+		panic("mismatch length")
+	}
+
+	var input []byte
+	for i := range a {
+		input = append(input, EVMBytes(a[i])...)
+		input = append(input, EVMBytes(b[i])...)
+	}
+
+	return input
+}
@@ -0,0 +1,91 @@
+package curvebn254
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/nikamn/BC-SSE/utils/curve"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurve_Pair(t *testing.T) {
+	c := BN254
+	a, b := big.NewInt(1234), big.NewInt(5678)
+
+	ga := c.NewG1().PowBig(c.G1(), a)
+	gb := c.NewG2().PowBig(c.G2(), b)
+
+	// e(g^a, g2^b) == e(g, g2)^(ab)
+	ab := new(big.Int).Mul(a, b)
+	e := c.NewGT().PowBig(c.Pair(c.G1(), c.G2()), ab)
+	assert.True(t, c.Pair(ga, gb).Equals(e), "bilinear")
+	assert.False(t, c.Pair(ga, c.G2()).Equals(e), "bilinear")
+
+	// group laws
+	sum := c.NewG1().Mul(ga, c.G1())
+	assert.True(t, sum.Equals(c.NewG1().PowBig(c.G1(), big.NewInt(1235))), "Mul")
+	assert.True(t, c.NewG1().Div(sum, c.G1()).Equals(ga), "Div")
+	assert.True(t, c.NewG1().Div(ga, ga).Is1(), "identity")
+	assert.True(t, c.NewG1().PowBig(c.G1(), c.Order()).Is1(), "order")
+	assert.True(t, c.NewG1().PowBig(c.G1(), big.NewInt(-1)).Equals(c.NewG1().Div(c.NewG1(), c.G1())), "negative exponent")
+	assert.True(t, c.NewGT().Div(e, e).Is1(), "GT identity")
+
+	assert.False(t, c.HashToG1([]byte("a")).Equals(c.HashToG1([]byte("b"))), "HashToG1")
+	assert.True(t, c.HashToG1([]byte("a")).Equals(c.HashToG1([]byte("a"))), "HashToG1")
+}
+
+func TestCurve_Bytes(t *testing.T) {
+	c := BN254
+
+	for _, x := range []struct {
+		el, decoded curve.Element
+		length      int
+	}{
+		{c.NewG1().PowBig(c.G1(), big.NewInt(99)), c.NewG1(), c.G1Length()},
+		{c.NewG2().PowBig(c.G2(), big.NewInt(99)), c.NewG2(), c.G2Length()},
+		{c.NewG1(), c.NewG1(), c.G1Length()},
+		{c.Pair(c.G1(), c.G2()), c.NewGT(), bn254.SizeOfGT},
+	} {
+		assert.Len(t, x.el.Bytes(), x.length)
+		assert.Nil(t, x.decoded.SetBytes(x.el.Bytes()), "SetBytes")
+		assert.Equal(t, x.el.Bytes(), x.decoded.Bytes())
+
+		assert.Nil(t, x.decoded.SetString(x.el.String()), "SetString")
+		assert.Equal(t, x.el.Bytes(), x.decoded.Bytes())
+
+		assert.NotNil(t, x.decoded.SetBytes(x.el.Bytes()[1:]), "truncated")
+	}
+}
+
+func TestEVMPairingInput(t *testing.T) {
+	c := BN254
+	a := big.NewInt(42)
+
+	// e(g^a, g2) e(g^-1, g2^a) == 1
+	g1s := []curve.Element{c.NewG1().PowBig(c.G1(), a), c.NewG1().Div(c.NewG1(), c.G1())}
+	g2s := []curve.Element{c.G2(), c.NewG2().PowBig(c.G2(), a)}
+
+	input := EVMPairingInput(g1s, g2s)
+	assert.Len(t, input, 2*192)
+
+	// decode as the precompile does and run the check
+	P := make([]bn254.G1Affine, 2)
+	Q := make([]bn254.G2Affine, 2)
+	for i := range P {
+		chunk := input[192*i : 192*(i+1)]
+		P[i].X.SetBytes(chunk[0:32])
+		P[i].Y.SetBytes(chunk[32:64])
+		Q[i].X.A1.SetBytes(chunk[64:96])
+		Q[i].X.A0.SetBytes(chunk[96:128])
+		Q[i].Y.A1.SetBytes(chunk[128:160])
+		Q[i].Y.A0.SetBytes(chunk[160:192])
+		assert.True(t, P[i].IsOnCurve() && Q[i].IsOnCurve(), "on curve")
+	}
+
+	ok, err := bn254.PairingCheck(P, Q)
+	assert.Nil(t, err)
+	assert.True(t, ok, "PairingCheck")
+
+	assert.Equal(t, make([]byte, 64), EVMBytes(c.NewG1()), "infinity")
+}
@@ -0,0 +1,89 @@
+package curve
+
+import (
+	"encoding/hex"
+	"math/big"
+)
+
+// Element is an element of G1, G2 or GT, written multiplicatively.
+// As in pbc, the methods set the receiver to the result and return it.
+// Elements of different groups or backends must not be mixed.
+//
+// Thread safety: an element may be read by several goroutines at once, e.g. the powers of an SRS,
+// but must not be written while another goroutine reads or writes it. Elements of the same Curve,
+// which share the pbc pairing in the pbc backend, can be used concurrently otherwise
+type Element interface {
+	// Set sets the element to x
+	Set(x Element) Element
+	// Set1 sets the element to the identity
+	Set1() Element
+	// Mul sets the element to x * y
+	Mul(x, y Element) Element
+	// Div sets the element to x / y
+	Div(x, y Element) Element
+	// PowBig sets the element to x^e
+	PowBig(x Element, e *big.Int) Element
+	// PreparePower precomputes a table that speeds up PowBig with the element as x, for fixed bases such as
+	// the powers of an SRS, and returns the element. Writing the element drops the table.
+	// Backends without precomputation return the element unchanged
+	PreparePower() Element
+
+	// Equals returns if the element equals x
+	Equals(x Element) bool
+	// Is1 returns if the element is the identity
+	Is1() bool
+
+	// Bytes returns the canonical, compressed where possible, encoding of the element
+	Bytes() []byte
+	// SetBytes sets the element to the one encoded by Bytes
+	SetBytes(buf []byte) error
+	// String returns a human readable form of the element
+	String() string
+	// SetString sets the element to the one printed by String
+	SetString(s string) error
+}
+
+// Curve is a pairing e: G1 x G2 -> GT over groups of prime order
+type Curve interface {
+	// Name identifies the curve in serialized data
+	Name() string
+	// Order returns the prime order r of the groups
+	Order() *big.Int
+	// Symmetric returns if G1 == G2, so that elements of G1 can be used as elements of G2
+	Symmetric() bool
+
+	// NewG1 returns the identity of G1
+	NewG1() Element
+	// NewG2 returns the identity of G2
+	NewG2() Element
+	// NewGT returns the identity of GT
+	NewGT() Element
+	// G1 returns the fixed generator of G1
+	G1() Element
+	// G2 returns the fixed generator of G2
+	G2() Element
+	// HashToG1 maps msg to an element of G1 with unknown discrete logarithm
+	HashToG1(msg []byte) Element
+
+	// Pair returns e(a, b) for a in G1 and b in G2
+	Pair(a, b Element) Element
+
+	// G1Length returns the length of Bytes of an element of G1
+	G1Length() int
+	// G2Length returns the length of Bytes of an element of G2
+	G2Length() int
+}
+
+// Hex returns Bytes of x in hex, the canonical text encoding of commitments and witnesses
+func Hex(x Element) string {
+	return hex.EncodeToString(x.Bytes())
+}
+
+// SetHex sets x to the element encoded by Hex
+func SetHex(x Element, s string) error {
+	buf, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	return x.SetBytes(buf)
+}
@@ -0,0 +1,209 @@
+package curvepbc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Nik-U/pbc"
+	"github.com/nikamn/BC-SSE/utils/curve"
+	"github.com/nikamn/BC-SSE/utils/ecparam"
+)
+
+// PBC256 is the Type A curve of ecparam.PBC256
+var PBC256 = New(ecparam.PBC256, "pbc-a-256")
+
+// Curve wraps a pbc pairing with a fixed generator.
+// pbc only reads the pairing once it is initialized, so goroutines may share it, and the Curve, to work on
+// distinct elements, e.g. to compute the witnesses of several points at once. An element written by one goroutine
+// must not be used by another at the same time, pbc has no locking
+type Curve struct {
+	params ecparam.ECParams
+	name   string
+}
+
+// New returns the curve of params, identified by name in serialized data
+func New(params ecparam.ECParams, name string) *Curve {
+	return &Curve{params: params, name: name}
+}
+
+// element is a pbc element, with the group it belongs to since GT elements have no compressed form
+type element struct {
+	e     *pbc.Element
+	isGT  bool
+	power *pbc.Power // table of PreparePower, nil once the element is written
+}
+
+// Element returns the pbc element underlying x
+func Element(x curve.Element) *pbc.Element {
+	return unwrap(x)
+}
+
+func unwrap(x curve.Element) *pbc.Element {
+	el, ok := x.(*element)
+	if !ok {
+		panic(fmt.Sprintf("not a pbc element: %T", x))
+	}
+	return el.e
+}
+
+// Set sets the element to x
+func (el *element) Set(x curve.Element) curve.Element {
+	el.e.Set(unwrap(x))
+	el.power = nil
+	return el
+}
+
+// Set1 sets the element to the identity
+func (el *element) Set1() curve.Element {
+	el.e.Set1()
+	el.power = nil
+	return el
+}
+
+// Mul sets the element to x * y
+func (el *element) Mul(x, y curve.Element) curve.Element {
+	el.e.Mul(unwrap(x), unwrap(y))
+	el.power = nil
+	return el
+}
+
+// Div sets the element to x / y
+func (el *element) Div(x, y curve.Element) curve.Element {
+	el.e.Div(unwrap(x), unwrap(y))
+	el.power = nil
+	return el
+}
+
+// PowBig sets the element to x^e, with the table of x if it was prepared
+func (el *element) PowBig(x curve.Element, e *big.Int) curve.Element {
+	if base, ok := x.(*element); ok && base.power != nil {
+		// the table takes exponents in Zr, which also reduces negative ones
+		el.e.PowerZn(base.power, el.e.Pairing().NewZr().SetBig(e))
+	} else {
+		el.e.PowBig(unwrap(x), e)
+	}
+	el.power = nil
+	return el
+}
+
+// PreparePower precomputes the pbc table for exponentiations with the element as base
+func (el *element) PreparePower() curve.Element {
+	el.power = el.e.PreparePower()
+	return el
+}
+
+// Equals returns if the element equals x
+func (el *element) Equals(x curve.Element) bool {
+	return el.e.Equals(unwrap(x))
+}
+
+// Is1 returns if the element is the identity
+func (el *element) Is1() bool {
+	return el.e.Is1()
+}
+
+// Bytes returns the compressed point, or the raw bytes of an element of GT
+func (el *element) Bytes() []byte {
+	if el.isGT {
+		return el.e.Bytes()
+	}
+	return el.e.CompressedBytes()
+}
+
+// SetBytes sets the element to the one encoded by Bytes
+func (el *element) SetBytes(buf []byte) error {
+	if el.isGT {
+		if len(buf) != el.e.BytesLen() {
+			return errors.New("invalid length")
+		}
+		el.e.SetBytes(buf)
+		el.power = nil
+		return nil
+	}
+
+	if len(buf) != el.e.CompressedBytesLen() {
+		return errors.New("invalid length")
+	}
+	el.e.SetCompressedBytes(buf)
+	el.power = nil
+
+	return nil
+}
+
+// String returns the element as printed by pbc
+func (el *element) String() string {
+	return el.e.String()
+}
+
+// SetString sets the element to the one printed by String
+func (el *element) SetString(s string) error {
+	if _, ok := el.e.SetString(s, 10); !ok {
+		return errors.New("invalid element")
+	}
+	el.power = nil
+	return nil
+}
+
+// Name identifies the curve in serialized data
+func (c *Curve) Name() string {
+	return c.name
+}
+
+// Order returns the prime order r of the groups
+func (c *Curve) Order() *big.Int {
+	return new(big.Int).Set(c.params.Nbig)
+}
+
+// Symmetric returns if G1 == G2
+func (c *Curve) Symmetric() bool {
+	return c.params.Pairing.IsSymmetric()
+}
+
+// NewG1 returns the identity of G1
+func (c *Curve) NewG1() curve.Element {
+	return &element{e: c.params.Pairing.NewG1().Set1()}
+}
+
+// NewG2 returns the identity of G2
+func (c *Curve) NewG2() curve.Element {
+	return &element{e: c.params.Pairing.NewG2().Set1()}
+}
+
+// NewGT returns the identity of GT
+func (c *Curve) NewGT() curve.Element {
+	return &element{e: c.params.Pairing.NewGT().Set1(), isGT: true}
+}
+
+// G1 returns the generator of ecparam
+func (c *Curve) G1() curve.Element {
+	return &element{e: c.params.Pairing.NewG1().Set(c.params.G)}
+}
+
+// G2 returns the generator of ecparam, which must be symmetric
+func (c *Curve) G2() curve.Element {
+	if !c.Symmetric() {
+		panic("G2 generator of an asymmetric pbc pairing is not set")
+	}
+	return &element{e: c.params.Pairing.NewG2().Set(c.params.G)}
+}
+
+// HashToG1 maps msg to an element of G1 with pbc's hash to the curve
+func (c *Curve) HashToG1(msg []byte) curve.Element {
+	return &element{e: c.params.Pairing.NewG1().SetFromHash(msg)}
+}
+
+// Pair returns e(a, b)
+func (c *Curve) Pair(a, b curve.Element) curve.Element {
+	return &element{e: c.params.Pairing.NewGT().Pair(unwrap(a), unwrap(b)), isGT: true}
+}
+
+// G1Length returns the length of a compressed element of G1
+func (c *Curve) G1Length() int {
+	return int(c.params.Pairing.G1CompressedLength())
+}
+
+// G2Length returns the length of a compressed element of G2
+func (c *Curve) G2Length() int {
+	return int(c.params.Pairing.G2CompressedLength())
+}
@@ -0,0 +1,289 @@
+package dkg
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/commitment"
+	"github.com/nikamn/BC-SSE/utils/conv"
+	"github.com/nikamn/BC-SSE/utils/curve"
+	commitpbc "github.com/nikamn/BC-SSE/utils/polycommit/pbc"
+	"github.com/nikamn/BC-SSE/utils/polypoint"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+)
+
+// Dealerless distributed key generation (Pedersen's DKG with GJKR-style complaint handling).
+// Parties are numbered 1..n and party i holds the evaluations at x = i.
+//  1. every party deals a random polynomial a_i of the given degree: it broadcasts the Feldman
+//     commitment to a_i and privately sends a_i(j) to party j (Commitment, ShareFor)
+//  2. every party checks what it received and broadcasts a complaint for each bad share (Receive)
+//  3. every accused dealer broadcasts the disputed shares (Answer), and all parties apply the same
+//     broadcast complaints and answers to agree on the qualified set (HandleComplaints)
+//  4. every party sums up the shares and commitments from the qualified set (Finalize)
+// The joint secret is sum a_i(0) over the qualified set. Nobody ever sees it, but anyone can
+// compute the public key g^secret from the broadcast commitments. It is never dealt as a share,
+// as x = 0 belongs to no party.
+
+// Complaint is broadcast by Complainer when the share dealt by Dealer does not verify
+type Complaint struct {
+	Dealer     int
+	Complainer int
+}
+
+// Answer is broadcast by Dealer to reveal the share of Complainer
+type Answer struct {
+	Dealer     int
+	Complainer int
+	Share      *gmp.Int
+}
+
+// Participant struct
+type Participant struct {
+	index  int
+	n      int
+	degree int
+	p      *gmp.Int
+
+	poly polyring.Polynomial
+	comm commitpbc.PolyCommit
+
+	// shares and commitments received from each dealer
+	shares map[int]*gmp.Int
+	comms  map[int]commitpbc.PolyCommit
+
+	disqualified map[int]bool
+}
+
+// NewParticipant returns party index (out of n) with a freshly sampled polynomial of the given degree.
+// rnd defaults to crypto/rand.Reader if nil
+func NewParticipant(index, n, degree int, rnd io.Reader) (*Participant, error) {
+	if index < 1 || index > n {
+		return nil, fmt.Errorf("index must be in [1, %d], got %d", n, index)
+	}
+
+	if degree < 0 || degree >= n {
+		return nil, fmt.Errorf("degree must be in [0, %d), got %d", n, degree)
+	}
+
+	p := conv.BigInt2GmpInt(commitpbc.Curve.Order())
+
+	poly, err := randomPoly(degree, commitpbc.Curve.Order(), rnd)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Participant{
+		index:        index,
+		n:            n,
+		degree:       degree,
+		p:            p,
+		poly:         poly,
+		comm:         commitpbc.NewPolyCommit(poly),
+		shares:       make(map[int]*gmp.Int),
+		comms:        make(map[int]commitpbc.PolyCommit),
+		disqualified: make(map[int]bool),
+	}, nil
+}
+
+// randomPoly returns a polynomial of the given degree with coefficients read from rnd in [0, order),
+// the highest one not zero
+func randomPoly(degree int, order *big.Int, rnd io.Reader) (polyring.Polynomial, error) {
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+
+	poly, err := polyring.New(degree)
+	if err != nil {
+		return polyring.Polynomial{}, err
+	}
+
+	for i := 0; i <= degree; i++ {
+		ci, err := rand.Int(rnd, order)
+		for err == nil && i == degree && ci.Sign() == 0 {
+			ci, err = rand.Int(rnd, order)
+		}
+		if err != nil {
+			return polyring.Polynomial{}, err
+		}
+
+		poly.SetCoefficientBig(i, conv.BigInt2GmpInt(ci))
+	}
+
+	return poly, nil
+}
+
+// CommitKZG returns the KZG commitment to the polynomial dealt by this party, the proof that its degree is
+// at most the threshold, and the witnesses of the shares of parties 1..n. They are linear in the polynomial,
+// so their products over the qualified set are those of the joint polynomial, which nobody knows
+func (pt *Participant) CommitKZG(c *commitment.DLPolyCommit) (C, degreeProof curve.Element, witnesses []curve.Element, err error) {
+	if c.GetCurve().Order().Cmp(conv.GmpInt2BigInt(pt.p)) != 0 {
+		return nil, nil, nil, errors.New("the KZG curve and the commitment curve have different orders")
+	}
+
+	C = c.NewG1()
+	c.Commit(C, pt.poly)
+
+	degreeProof = c.NewG1()
+	if err := c.CreateDegreeProof(degreeProof, pt.poly, pt.degree); err != nil {
+		return nil, nil, nil, err
+	}
+
+	xs := make([]*gmp.Int, pt.n)
+	witnesses = make([]curve.Element, pt.n)
+	for j := range xs {
+		xs[j] = gmp.NewInt(int64(j + 1))
+		witnesses[j] = c.NewG1()
+	}
+	if err := c.CreateWitnesses(witnesses, pt.poly, xs, 0); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return C, degreeProof, witnesses, nil
+}
+
+// SharePoint returns the share of party index as a point at x = index, with the witness w, which may be nil
+func SharePoint(index int, share *gmp.Int, w curve.Element) *polypoint.PolyPoint {
+	return polypoint.NewPoint(int32(index), share, w)
+}
+
+// Index returns the party index
+func (pt *Participant) Index() int {
+	return pt.index
+}
+
+// Commitment returns the commitment to the polynomial dealt by this party
+func (pt *Participant) Commitment() commitpbc.PolyCommit {
+	return pt.comm
+}
+
+// ShareFor returns a_i(j), the share this party deals to party j
+func (pt *Participant) ShareFor(j int) *gmp.Int {
+	share := gmp.NewInt(0)
+	pt.poly.EvalMod(gmp.NewInt(int64(j)), pt.p, share)
+
+	return share
+}
+
+// verify checks share against comm at x = j
+func (pt *Participant) verify(comm commitpbc.PolyCommit, j int, share *gmp.Int) bool {
+	if share == nil || comm.GetDegree() != pt.degree {
+		return false
+	}
+
+	return comm.VerifyEval(conv.GmpInt2BigInt(gmp.NewInt(int64(j))), conv.GmpInt2BigInt(share))
+}
+
+// Receive records the commitment and share dealt by dealer, returning a complaint if they do not verify.
+// A missing share is passed as nil
+func (pt *Participant) Receive(dealer int, comm commitpbc.PolyCommit, share *gmp.Int) *Complaint {
+	pt.comms[dealer] = comm
+
+	if !pt.verify(comm, pt.index, share) {
+		return &Complaint{Dealer: dealer, Complainer: pt.index}
+	}
+
+	pt.shares[dealer] = share
+
+	return nil
+}
+
+// Answer reveals the share of the complainer, which the accused dealer must broadcast
+func (pt *Participant) Answer(c Complaint) Answer {
+	return Answer{Dealer: pt.index, Complainer: c.Complainer, Share: pt.ShareFor(c.Complainer)}
+}
+
+// HandleComplaints applies the broadcast complaints and answers. A dealer is disqualified if it
+// has more than degree complaints, or if it leaves a complaint unanswered or answers it with a
+// share that does not verify. Otherwise the complainer adopts the revealed share.
+// Every honest party sees the same broadcast, so every honest party ends up with the same qualified set
+func (pt *Participant) HandleComplaints(complaints []Complaint, answers []Answer) {
+	accused := make(map[int]map[int]bool)
+	for _, c := range complaints {
+		if accused[c.Dealer] == nil {
+			accused[c.Dealer] = make(map[int]bool)
+		}
+		accused[c.Dealer][c.Complainer] = true
+	}
+
+	for dealer, complainers := range accused {
+		if len(complainers) > pt.degree {
+			pt.disqualified[dealer] = true
+			continue
+		}
+
+		for complainer := range complainers {
+			var answer *Answer
+			for i := range answers {
+				if answers[i].Dealer == dealer && answers[i].Complainer == complainer {
+					answer = &answers[i]
+					break
+				}
+			}
+
+			if answer == nil || !pt.verify(pt.comms[dealer], complainer, answer.Share) {
+				pt.disqualified[dealer] = true
+				break
+			}
+
+			if complainer == pt.index {
+				pt.shares[dealer] = answer.Share
+			}
+		}
+	}
+
+	// a dealer that never sent a commitment can't be part of the qualified set either
+	for dealer := 1; dealer <= pt.n; dealer++ {
+		if _, ok := pt.comms[dealer]; !ok {
+			pt.disqualified[dealer] = true
+		}
+	}
+}
+
+// QualifiedSet returns the sorted indices of the dealers that were not disqualified
+func (pt *Participant) QualifiedSet() []int {
+	qual := make([]int, 0, pt.n)
+	for dealer := 1; dealer <= pt.n; dealer++ {
+		if !pt.disqualified[dealer] {
+			qual = append(qual, dealer)
+		}
+	}
+	sort.Ints(qual)
+
+	return qual
+}
+
+// Finalize returns this party's share of the joint secret and the commitment to the joint polynomial
+func (pt *Participant) Finalize() (*gmp.Int, commitpbc.PolyCommit, error) {
+	qual := pt.QualifiedSet()
+	if len(qual) == 0 {
+		return nil, commitpbc.PolyCommit{}, errors.New("empty qualified set")
+	}
+
+	share := gmp.NewInt(0)
+	comm := pt.comms[qual[0]]
+
+	for i, dealer := range qual {
+		s, ok := pt.shares[dealer]
+		if !ok {
+			return nil, commitpbc.PolyCommit{}, fmt.Errorf("missing share from qualified dealer %d", dealer)
+		}
+		share.Add(share, s)
+
+		if i > 0 {
+			comm = commitpbc.AdditiveHomomorphism(comm, pt.comms[dealer])
+		}
+	}
+	share.Mod(share, pt.p)
+
+	return share, comm, nil
+}
+
+// PublicKey returns g^secret of the jointly generated secret from the commitment returned by Finalize
+func PublicKey(comm commitpbc.PolyCommit) curve.Element {
+	return comm.GetPtrToConstant()
+}
@@ -0,0 +1,185 @@
+package dkg
+
+import (
+	"fmt"
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/commitment"
+	"github.com/nikamn/BC-SSE/utils/conv"
+	"github.com/nikamn/BC-SSE/utils/curve"
+	"github.com/nikamn/BC-SSE/utils/interpolation"
+	"github.com/nikamn/BC-SSE/utils/intrinsic"
+	commitpbc "github.com/nikamn/BC-SSE/utils/polycommit/pbc"
+	"github.com/nikamn/BC-SSE/utils/polypoint"
+	"github.com/stretchr/testify/assert"
+)
+
+const RandSeed = 5
+
+func TestDKG(t *testing.T) {
+	const n = 5
+	const degree = 2
+	rnd := rand.New(rand.NewSource(RandSeed))
+
+	parties := make([]*Participant, n)
+	for i := range parties {
+		pt, err := NewParticipant(i+1, n, degree, rnd)
+		assert.Nil(t, err, "NewParticipant")
+		parties[i] = pt
+	}
+
+	// dealer 3 sends a bad share to party 1 only, dealer 5 to more than degree parties
+	bad := map[int][]int{3: {1}, 5: {1, 2, 4}}
+	isBad := func(dealer, receiver int) bool {
+		for _, j := range bad[dealer] {
+			if j == receiver {
+				return true
+			}
+		}
+		return false
+	}
+
+	var complaints []Complaint
+	for _, dealer := range parties {
+		for _, receiver := range parties {
+			share := dealer.ShareFor(receiver.Index())
+			if isBad(dealer.Index(), receiver.Index()) {
+				share.Add(share, gmp.NewInt(1))
+			}
+			if c := receiver.Receive(dealer.Index(), dealer.Commitment(), share); c != nil {
+				complaints = append(complaints, *c)
+			}
+		}
+	}
+	assert.Equal(t, 4, len(complaints), "complaints")
+
+	// dealer 5 refuses to answer
+	var answers []Answer
+	for _, c := range complaints {
+		if c.Dealer != 5 {
+			answers = append(answers, parties[c.Dealer-1].Answer(c))
+		}
+	}
+
+	for _, pt := range parties {
+		pt.HandleComplaints(complaints, answers)
+		assert.Equal(t, []int{1, 2, 3, 4}, pt.QualifiedSet(), "qualified set")
+	}
+
+	x := make([]*gmp.Int, n)
+	y := make([]*gmp.Int, n)
+	var joint commitpbc.PolyCommit
+	for i, pt := range parties {
+		share, comm, err := pt.Finalize()
+		assert.Nil(t, err, "Finalize")
+
+		if i == 0 {
+			joint = comm
+		}
+		assert.True(t, joint.Equals(comm), "every party agrees on the joint commitment")
+
+		x[i] = gmp.NewInt(int64(pt.Index()))
+		y[i] = share
+		assert.True(t, comm.VerifyEval(conv.GmpInt2BigInt(x[i]), conv.GmpInt2BigInt(y[i])), "share verifies")
+	}
+
+	// any degree+1 parties reconstruct the secret behind the public key
+	secretPoly, err := interpolation.LagrangeInterpolate(degree, x[2:], y[2:], conv.BigInt2GmpInt(commitpbc.Curve.Order()))
+	assert.Nil(t, err, "LagrangeInterpolate")
+
+	pk := commitpbc.Curve.NewG1()
+	pk.PowBig(commitpbc.Curve.G1(), conv.GmpInt2BigInt(secretPoly.GetPtrToConstant()))
+	assert.True(t, pk.Equals(PublicKey(joint)), "public key")
+}
+
+func TestDKG_SavedShares(t *testing.T) {
+	const n = 5
+	const degree = 2
+	rnd := rand.New(rand.NewSource(RandSeed))
+
+	c := new(commitment.DLPolyCommit)
+	c.SetupFix(degree + 2)
+
+	parties := make([]*Participant, n)
+	for i := range parties {
+		pt, err := NewParticipant(i+1, n, degree, rnd)
+		assert.Nil(t, err, "NewParticipant")
+		parties[i] = pt
+	}
+	for _, dealer := range parties {
+		for _, receiver := range parties {
+			assert.Nil(t, receiver.Receive(dealer.Index(), dealer.Commitment(), dealer.ShareFor(receiver.Index())), "honest dealer")
+		}
+	}
+
+	// the KZG data of the joint polynomial is the product of that of the dealers
+	C, degreeProof := c.NewG1(), c.NewG1()
+	C.Set1()
+	degreeProof.Set1()
+	witnesses := make([]curve.Element, n)
+	for j := range witnesses {
+		witnesses[j] = c.NewG1()
+		witnesses[j].Set1()
+	}
+	for _, dealer := range parties {
+		dealerC, dealerProof, dealerWitnesses, err := dealer.CommitKZG(c)
+		assert.Nil(t, err, "CommitKZG")
+		C.Mul(C, dealerC)
+		degreeProof.Mul(degreeProof, dealerProof)
+		for j := range witnesses {
+			witnesses[j].Mul(witnesses[j], dealerWitnesses[j])
+		}
+	}
+	assert.True(t, c.VerifyDegree(C, degree, degreeProof), "degree proof")
+
+	dir := t.TempDir()
+	var joint commitpbc.PolyCommit
+	for _, pt := range parties {
+		pt.HandleComplaints(nil, nil)
+		share, comm, err := pt.Finalize()
+		assert.Nil(t, err, "Finalize")
+		joint = comm
+
+		path := filepath.Join(dir, fmt.Sprintf("party%d", pt.Index()))
+		assert.Nil(t, intrinsic.Save(path, SharePoint(pt.Index(), share, witnesses[pt.Index()-1])), "Save")
+	}
+
+	// reconstruct the secret from the saved shares, at the x they were saved at
+	x := make([]*gmp.Int, n)
+	y := make([]*gmp.Int, n)
+	for i := range x {
+		point := polypoint.NewPoint(0, gmp.NewInt(0), c.NewG1())
+		assert.Nil(t, intrinsic.Load(filepath.Join(dir, fmt.Sprintf("party%d", i+1)), point), "Load")
+		assert.Equal(t, int32(i+1), point.X, "party %d is at x = %d", i+1, i+1)
+
+		x[i] = gmp.NewInt(int64(point.X))
+		y[i] = point.Y
+		assert.True(t, c.VerifyEval(C, x[i], y[i], point.PolyWit), "witness of party %d", i+1)
+	}
+
+	secretPoly, err := interpolation.LagrangeInterpolate(degree, x[:degree+1], y[:degree+1], conv.BigInt2GmpInt(commitpbc.Curve.Order()))
+	assert.Nil(t, err, "LagrangeInterpolate")
+	assert.True(t, c.VerifyPoly(C, secretPoly), "KZG commitment to the joint polynomial")
+
+	pk := commitpbc.Curve.NewG1()
+	pk.PowBig(commitpbc.Curve.G1(), conv.GmpInt2BigInt(secretPoly.GetPtrToConstant()))
+	assert.True(t, pk.Equals(PublicKey(joint)), "g^secret is the public key")
+}
+
+func TestNewParticipant(t *testing.T) {
+	rnd := rand.New(rand.NewSource(RandSeed))
+
+	_, err := NewParticipant(0, 5, 2, rnd)
+	assert.NotNil(t, err, "index out of range")
+
+	_, err = NewParticipant(1, 5, 5, rnd)
+	assert.NotNil(t, err, "degree out of range")
+
+	pt, err := NewParticipant(1, 5, 2, nil)
+	assert.Nil(t, err, "crypto/rand")
+	assert.True(t, pt.Commitment().Verify(pt.poly), "commitment to the sampled polynomial")
+	assert.Equal(t, 2, pt.poly.GetDegree(), "degree")
+}
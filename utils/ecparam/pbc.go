@@ -1,7 +1,10 @@
 package ecparam
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
+	"strings"
 
 	"github.com/Nik-U/pbc"
 	"github.com/ncw/gmp"
@@ -12,12 +15,10 @@ var PBC256 = InitializeParams()
 
 const configString = "type a q 7551229346118097707657055192679868878245809937493679053434400908343538795134604198250280897597927293593086560738424067354362094283307245214081272453750739 h 130427378862502999532171986493880300490778513023419182702204867119101398441940 r 57896044618658097711785492504343953926634992332820282019728792006155588075521 exp2 255 exp1 41 sign1 1 sign0 1"
 
-const order = "57896044618658097711785492504343953926634992332820282019728792006155588075521"
-
 // ECParams struct
 type ECParams struct {
 	Params  *pbc.Params
-	Pairing *pbc.Pairing
+	Pairing *pbc.Pairing // read only after initialization, so it can be shared by goroutines, unlike its elements
 	Nbig    *big.Int
 	Ngmp    *gmp.Int
 	G       *pbc.Element
@@ -25,21 +26,58 @@ type ECParams struct {
 
 // InitializeParams initializes parameters
 func InitializeParams() ECParams {
-	p, err := pbc.NewParamsFromString(configString)
+	pp, err := NewECParams(configString)
 	if err != nil {
 		panic(err.Error())
 	}
 
+	pp.G.SetString("[4133724144590655254194602165057338253581374248311829415804358586850519521096709820505371851539736973052316311123290392470565023776459368655389261216524371, 3477043631151308457697380491861699444387375269849172071603708732050642928211953792333616861215138231339668243778249230704600690552449532564174180095431116]", 10)
+
+	return pp
+}
+
+// NewECParams initializes parameters from a pbc parameter string, with a random generator G
+func NewECParams(config string) (ECParams, error) {
+	order, err := parseOrder(config)
+	if err != nil {
+		return ECParams{}, err
+	}
+
+	p, err := pbc.NewParamsFromString(config)
+	if err != nil {
+		return ECParams{}, err
+	}
+
 	var pp ECParams
 
 	pp.Params = p
 	pp.Pairing = p.NewPairing()
-	pp.Nbig = big.NewInt(0)
-	pp.Nbig.SetString(order, 10)
+	pp.Nbig = order
 	pp.Ngmp = gmp.NewInt(0)
-	pp.Ngmp.SetString(order, 10)
-	pp.G = pp.Pairing.NewG1()
-	pp.G.SetString("[4133724144590655254194602165057338253581374248311829415804358586850519521096709820505371851539736973052316311123290392470565023776459368655389261216524371, 3477043631151308457697380491861699444387375269849172071603708732050642928211953792333616861215138231339668243778249230704600690552449532564174180095431116]", 10)
+	pp.Ngmp.SetString(order.String(), 10)
+	pp.G = pp.Pairing.NewG1().Rand()
 
-	return pp
+	return pp, nil
+}
+
+// GenerateA generates fresh Type A parameters: the group order is a prime of about rbits bits
+// and the base field is F_q where q has about qbits bits. Suggested sizes are rbits = 160, qbits = 512
+func GenerateA(rbits, qbits uint32) (ECParams, error) {
+	return NewECParams(pbc.GenerateA(rbits, qbits).String())
+}
+
+// parseOrder returns the group order r of a pbc parameter string
+func parseOrder(config string) (*big.Int, error) {
+	fields := strings.Fields(config)
+	for i := 0; i+1 < len(fields); i++ {
+		if fields[i] == "r" {
+			order, ok := new(big.Int).SetString(fields[i+1], 10)
+			if !ok {
+				return nil, fmt.Errorf("invalid group order %q", fields[i+1])
+			}
+			return order, nil
+		}
+	}
+
+	return nil, errors.New("parameters have no group order")
 }
@@ -0,0 +1,30 @@
+package ecparam
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPBC256(t *testing.T) {
+	assert.Equal(t, 255, PBC256.Nbig.BitLen())
+	assert.Equal(t, PBC256.Nbig.String(), PBC256.Ngmp.String())
+
+	// g^r == 1
+	g := PBC256.Pairing.NewG1().PowBig(PBC256.G, PBC256.Nbig)
+	assert.True(t, g.Is1(), "order of G")
+}
+
+func TestGenerateA(t *testing.T) {
+	pp, err := GenerateA(160, 512)
+	assert.Nil(t, err, "GenerateA")
+	assert.InDelta(t, 160, pp.Nbig.BitLen(), 1)
+	assert.Equal(t, pp.Nbig.String(), pp.Ngmp.String())
+
+	g := pp.Pairing.NewG1().PowBig(pp.G, pp.Nbig)
+	assert.True(t, g.Is1(), "order of G")
+	assert.False(t, pp.G.Is1(), "G is not the identity")
+
+	_, err = NewECParams("type a q 7 h 2")
+	assert.NotNil(t, err, "no group order")
+}
@@ -0,0 +1,197 @@
+package interpolation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+)
+
+// RobustInterpolate returns the polynomial of specified degree that passes through all but at most
+// (n-degree-1)/2 of the n points in x and y, together with the indices of the points it does not pass
+// through. This is Berlekamp-Welch decoding of the Reed-Solomon code formed by the shares.
+func RobustInterpolate(degree int, x []*gmp.Int, y []*gmp.Int, mod *gmp.Int) (polyring.Polynomial, []int, error) {
+	n := len(x)
+	if len(y) != n {
+		return polyring.Polynomial{}, nil, errors.New("mismatch length")
+	}
+
+	if n < degree+1 {
+		return polyring.Polynomial{}, nil, fmt.Errorf("need at least %d points, got %d", degree+1, n)
+	}
+
+	seen := make(map[string]struct{}, n)
+	for _, xi := range x {
+		tmp := gmp.NewInt(0)
+		tmp.Mod(xi, mod)
+		if _, ok := seen[tmp.String()]; ok {
+			return polyring.Polynomial{}, nil, errors.New("duplication in x[]")
+		}
+		seen[tmp.String()] = struct{}{}
+	}
+
+	// maximum number of errors that can be corrected
+	e := (n - degree - 1) / 2
+
+	// Find an error locator E(x) = x^e + e_{e-1} x^{e-1} + ... + e_0 and Q(x) of degree degree+e
+	// such that Q(x_i) = y_i * E(x_i) for all i.
+	// Unknowns are ordered as q_0, ..., q_{degree+e}, e_0, ..., e_{e-1}.
+	cols := degree + 2*e + 1
+	A := make([][]*gmp.Int, n)
+	b := make([]*gmp.Int, n)
+
+	for i := 0; i < n; i++ {
+		A[i] = make([]*gmp.Int, cols)
+		polyring.VecInit(A[i])
+
+		xPow := gmp.NewInt(1)
+		for k := 0; k <= degree+e; k++ {
+			A[i][k].Set(xPow)
+
+			// -y_i * x_i^k for the error locator coefficients
+			if k < e {
+				A[i][degree+e+1+k].Mul(y[i], xPow)
+				A[i][degree+e+1+k].Neg(A[i][degree+e+1+k])
+				A[i][degree+e+1+k].Mod(A[i][degree+e+1+k], mod)
+			}
+
+			// y_i * x_i^e on the right hand side
+			if k == e {
+				b[i] = gmp.NewInt(0)
+				b[i].Mul(y[i], xPow)
+				b[i].Mod(b[i], mod)
+			}
+
+			xPow.Mul(xPow, x[i])
+			xPow.Mod(xPow, mod)
+		}
+	}
+
+	solution, err := solveMod(A, b, mod)
+	if err != nil {
+		return polyring.Polynomial{}, nil, errors.New("too many errors to decode")
+	}
+
+	Q, err := polyring.New(degree + e)
+	if err != nil {
+		return polyring.Polynomial{}, nil, err
+	}
+	for k := 0; k <= degree+e; k++ {
+		Q.SetCoefficientBig(k, solution[k])
+	}
+
+	E, err := polyring.New(e)
+	if err != nil {
+		return polyring.Polynomial{}, nil, err
+	}
+	E.SetCoefficient(e, 1)
+	for k := 0; k < e; k++ {
+		E.SetCoefficientBig(k, solution[degree+e+1+k])
+	}
+
+	P, r := polyring.NewEmpty(), polyring.NewEmpty()
+	err = polyring.DivMod(Q, E, mod, &P, &r)
+	if err != nil {
+		return polyring.Polynomial{}, nil, err
+	}
+
+	if !r.IsZero() || P.GetDegree() > degree {
+		return polyring.Polynomial{}, nil, errors.New("too many errors to decode")
+	}
+
+	// the points P does not pass through are the faulty ones
+	var faulty []int
+	eval := gmp.NewInt(0)
+	for i := 0; i < n; i++ {
+		P.EvalMod(x[i], mod, eval)
+		tmp := gmp.NewInt(0)
+		tmp.Mod(y[i], mod)
+		if eval.Cmp(tmp) != 0 {
+			faulty = append(faulty, i)
+		}
+	}
+
+	if len(faulty) > e {
+		return polyring.Polynomial{}, nil, errors.New("too many errors to decode")
+	}
+
+	return P, faulty, nil
+}
+
+// solveMod returns a solution to A * s = b mod p, setting free variables to zero
+func solveMod(A [][]*gmp.Int, b []*gmp.Int, p *gmp.Int) ([]*gmp.Int, error) {
+	rows := len(A)
+	cols := len(A[0])
+
+	// augmented matrix [A | b]
+	M := make([][]*gmp.Int, rows)
+	for i := range M {
+		M[i] = make([]*gmp.Int, cols+1)
+		for j := 0; j < cols; j++ {
+			M[i][j] = gmp.NewInt(0)
+			M[i][j].Mod(A[i][j], p)
+		}
+		M[i][cols] = gmp.NewInt(0)
+		M[i][cols].Mod(b[i], p)
+	}
+
+	inv := gmp.NewInt(0)
+	tmp := gmp.NewInt(0)
+	pivots := make([]int, 0, cols)
+
+	row := 0
+	for col := 0; col < cols && row < rows; col++ {
+		// find a pivot in this column
+		pivot := -1
+		for i := row; i < rows; i++ {
+			if M[i][col].CmpInt32(0) != 0 {
+				pivot = i
+				break
+			}
+		}
+		if pivot < 0 {
+			continue
+		}
+		M[row], M[pivot] = M[pivot], M[row]
+
+		// normalize the pivot row
+		inv.ModInverse(M[row][col], p)
+		for j := col; j <= cols; j++ {
+			M[row][j].Mul(M[row][j], inv)
+			M[row][j].Mod(M[row][j], p)
+		}
+
+		// eliminate the column from all other rows
+		for i := 0; i < rows; i++ {
+			if i == row || M[i][col].CmpInt32(0) == 0 {
+				continue
+			}
+			factor := gmp.NewInt(0)
+			factor.Set(M[i][col])
+			for j := col; j <= cols; j++ {
+				tmp.Mul(factor, M[row][j])
+				M[i][j].Sub(M[i][j], tmp)
+				M[i][j].Mod(M[i][j], p)
+			}
+		}
+
+		pivots = append(pivots, col)
+		row++
+	}
+
+	// a zero row with a non-zero right hand side means there is no solution
+	for i := row; i < rows; i++ {
+		if M[i][cols].CmpInt32(0) != 0 {
+			return nil, errors.New("inconsistent system")
+		}
+	}
+
+	solution := make([]*gmp.Int, cols)
+	polyring.VecInit(solution)
+	for i, col := range pivots {
+		solution[col].Set(M[i][cols])
+	}
+
+	return solution, nil
+}
@@ -0,0 +1,75 @@
+package interpolation
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRobustInterpolate(t *testing.T) {
+	const degree = 5
+	const n = 16
+	p := gmp.NewInt(15486511)
+	r := rand.New(rand.NewSource(RandSeed))
+
+	originalPoly, err := polyring.NewRand(degree, r, p)
+	assert.Nil(t, err, "NewRand")
+
+	x := make([]*gmp.Int, n)
+	y := make([]*gmp.Int, n)
+	polyring.VecInit(y)
+	for i := range x {
+		x[i] = gmp.NewInt(int64(i + 1))
+	}
+	originalPoly.EvalModArray(x, p, y)
+
+	// no errors
+	decoded, faulty, err := RobustInterpolate(degree, x, y, p)
+	assert.Nil(t, err, "RobustInterpolate")
+	assert.Empty(t, faulty)
+	assert.True(t, decoded.IsSame(originalPoly))
+
+	// up to (n-degree-1)/2 = 5 errors are corrected
+	bad := []int{0, 3, 7, 8, 15}
+	for _, i := range bad {
+		y[i].Add(y[i], gmp.NewInt(int64(i+1)))
+		y[i].Mod(y[i], p)
+	}
+
+	decoded, faulty, err = RobustInterpolate(degree, x, y, p)
+	assert.Nil(t, err, "RobustInterpolate")
+	assert.Equal(t, bad, faulty, "faulty indices")
+	assert.True(t, decoded.IsSame(originalPoly))
+
+	// one more error is detected, but can't be corrected
+	y[10].Add(y[10], gmp.NewInt(1))
+	_, _, err = RobustInterpolate(degree, x, y, p)
+	assert.NotNil(t, err, "too many errors")
+}
+
+func TestRobustInterpolate_Exact(t *testing.T) {
+	const degree = 3
+	p := gmp.NewInt(15486511)
+
+	// with exactly degree+1 points there is no redundancy, so any points decode
+	x := []*gmp.Int{gmp.NewInt(1), gmp.NewInt(2), gmp.NewInt(3), gmp.NewInt(4)}
+	y := []*gmp.Int{gmp.NewInt(10), gmp.NewInt(20), gmp.NewInt(35), gmp.NewInt(1)}
+
+	decoded, faulty, err := RobustInterpolate(degree, x, y, p)
+	assert.Nil(t, err, "RobustInterpolate")
+	assert.Empty(t, faulty)
+
+	expected, err := LagrangeInterpolate(degree, x, y, p)
+	assert.Nil(t, err, "LagrangeInterpolate")
+	assert.True(t, decoded.IsSame(expected))
+
+	_, _, err = RobustInterpolate(degree, x[:3], y[:3], p)
+	assert.NotNil(t, err, "not enough points")
+
+	x[1] = gmp.NewInt(1)
+	_, _, err = RobustInterpolate(degree, x, y, p)
+	assert.NotNil(t, err, "duplication in x")
+}
@@ -23,7 +23,81 @@ func Deduplicate(s []int) []int {
 }
 
 // LagrangeInterpolate returns a polynomial of specified degree that pass through all points in x and y
+// Only the first degree+1 points are used, see RobustInterpolate to use them all.
+// If mod is prime, it uses the polyring.Field of mod, and from polyring.MultipointThreshold points on a
+// polyring.SubproductTree. Otherwise the differences of the x must be invertible mod mod
 func LagrangeInterpolate(degree int, x []*gmp.Int, y []*gmp.Int, mod *gmp.Int) (polyring.Polynomial, error) {
+	if len(x) < degree+1 || len(y) < degree+1 {
+		return polyring.Polynomial{}, errors.New("not enough points")
+	}
+
+	if mod == nil || mod.CmpInt32(2) < 0 {
+		return polyring.Polynomial{}, errors.New("modulus must be at least 2")
+	}
+
+	field, err := polyring.GetField(mod)
+	if err != nil {
+		return lagrangeInterpolateRing(degree, x, y, mod)
+	}
+
+	// many points are interpolated in O(n log^2 n) with a subproduct tree
+	if degree+1 >= polyring.MultipointThreshold {
+		tree, err := field.NewSubproductTree(x[:degree+1])
+		if err != nil {
+			return polyring.Polynomial{}, err
+		}
+
+		resultPoly, err := tree.Interpolate(y[:degree+1])
+		if err != nil {
+			return polyring.Polynomial{}, errors.New("internal error: check duplication in x[]")
+		}
+
+		return resultPoly, nil
+	}
+
+	// initialize variables
+	tmp, err := polyring.New(1)
+	if err != nil {
+		return polyring.Polynomial{}, err
+	}
+
+	inter := polyring.NewEmpty()
+	resultPoly := polyring.NewEmpty()
+
+	denominator := gmp.NewInt(0)
+
+	// product(x) = (x - x[0]) ... (x - x[degree]), note only the first degree points are used
+	product := field.FromRoots(x[:degree+1])
+
+	// tmp(x) = x - x[i]
+	tmp.SetCoefficient(1, 1)
+
+	for i := 0; i <= degree; i++ {
+		// tmp = x - x[i]
+		tmp.GetPtrToConstant().Neg(x[i])
+
+		// inner(x) = (x-1)(x_2)...(x-n) except for (x-i)
+		err = field.Div2(&inter, product, tmp)
+		if err != nil {
+			return polyring.Polynomial{}, err
+		}
+
+		// lambda_i(x) = inner(x) * y[i] / inner(x[i])
+		field.Eval(denominator, inter, x[i])
+
+		if field.Inverse(denominator, denominator) != nil {
+			return polyring.Polynomial{}, errors.New("internal error: check duplication in x[]")
+		}
+
+		denominator.Mul(denominator, y[i])
+		field.AddMul(&resultPoly, inter, denominator)
+	}
+
+	return resultPoly, nil
+}
+
+// lagrangeInterpolateRing is LagrangeInterpolate in Z_mod for a mod that is not prime, as it was before the Field
+func lagrangeInterpolateRing(degree int, x []*gmp.Int, y []*gmp.Int, mod *gmp.Int) (polyring.Polynomial, error) {
 	// initialize variables
 	tmp, err := polyring.New(1)
 	if err != nil {
@@ -43,21 +117,17 @@ func LagrangeInterpolate(degree int, x []*gmp.Int, y []*gmp.Int, mod *gmp.Int) (
 	}
 
 	denominator := gmp.NewInt(0)
+	gcd := gmp.NewInt(0)
 
-	// tmp(x) = x - x[i]
+	// tmp(x) = x - x[i], note only the first degree points are used
 	tmp.SetCoefficient(1, 1)
-	// note only the first degree points are used
 	for i := 0; i <= degree; i++ {
 		tmp.GetPtrToConstant().Neg(x[i])
 		product.MulSelf(tmp)
 	}
 
 	for i := 0; i <= degree; i++ {
-		denominator.Set(gmp.NewInt(1))
-		// compute denominator and numerator
-
 		// tmp = x - x[i]
-		tmp.SetCoefficient(1, 1) // i don't think this needed...
 		tmp.GetPtrToConstant().Neg(x[i])
 
 		// inner(x) = (x-1)(x_2)...(x-n) except for (x-i)
@@ -67,13 +137,11 @@ func LagrangeInterpolate(degree int, x []*gmp.Int, y []*gmp.Int, mod *gmp.Int) (
 		}
 
 		// lambda_i(x) = inner(x) * y[i] / inner(x[i])
-
 		inter.Mod(mod)
 		inter.EvalMod(x[i], mod, denominator)
 
-		// panic if denominator == 0
-		if 0 == denominator.CmpInt32(0) {
-			return polyring.Polynomial{}, errors.New("internal error: check duplication in x[]")
+		if gcd.GCD(nil, nil, denominator, mod).CmpInt32(1) != 0 {
+			return polyring.Polynomial{}, errors.New("the differences of x[] must be invertible mod mod")
 		}
 
 		denominator.ModInverse(denominator, mod)
@@ -63,3 +63,26 @@ func TestLagrangeInterpolate(t *testing.T) {
 	//reconstructedPoly.Print()
 	assert.True(t, reconstructedPoly.IsSame(originalPoly))
 }
+
+func TestLagrangeInterpolate_Composite(t *testing.T) {
+	// 35 is not prime, the differences of 0, 1 and 3 are still invertible
+	mod := gmp.NewInt(35)
+	originalPoly := polyring.FromVec(3, 5, 7)
+
+	x := []*gmp.Int{gmp.NewInt(0), gmp.NewInt(1), gmp.NewInt(3)}
+	y := make([]*gmp.Int, len(x))
+	polyring.VecInit(y)
+	originalPoly.EvalModArray(x, mod, y)
+
+	reconstructedPoly, err := LagrangeInterpolate(2, x, y, mod)
+	assert.Nil(t, err, "LagrangeInterpolate")
+	assert.True(t, reconstructedPoly.IsSame(originalPoly), "composite modulus")
+
+	// 5 - 0 is not invertible mod 35
+	x[2].SetInt64(5)
+	_, err = LagrangeInterpolate(2, x, y, mod)
+	assert.NotNil(t, err, "non-invertible difference")
+
+	_, err = LagrangeInterpolate(2, x, y, gmp.NewInt(1))
+	assert.NotNil(t, err, "modulus 1")
+}
@@ -0,0 +1,87 @@
+// Package parallel runs loops on a pool of worker goroutines.
+// The loop bodies must only share data that they read: see the thread-safety notes of curve.Element
+package parallel
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Workers is the number of goroutines used when a function is given w <= 0. If it is 0 too, the functions use
+// GOMAXPROCS at the time of the call
+var Workers = 0
+
+// workers returns the number of goroutines to use for n iterations
+func workers(n int, w int) int {
+	if w <= 0 {
+		w = Workers
+	}
+	if w <= 0 {
+		w = runtime.GOMAXPROCS(0)
+	}
+	if w > n {
+		w = n
+	}
+	if w < 1 {
+		w = 1
+	}
+	return w
+}
+
+// For calls f(i) for i in [0, n) on a pool of w goroutines, w <= 0 meaning Workers, and returns when all calls
+// have returned. Each goroutine takes the next index from a shared counter, so that it suits iterations of
+// uneven cost such as witnesses
+func For(n int, w int, f func(i int)) {
+	if n <= 0 {
+		return
+	}
+
+	w = workers(n, w)
+	if w == 1 {
+		for i := 0; i < n; i++ {
+			f(i)
+		}
+		return
+	}
+
+	indices := make(chan int, n)
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+
+	var wg sync.WaitGroup
+	wg.Add(w)
+	for k := 0; k < w; k++ {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				f(i)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Chunks splits [0, n) into at most w contiguous ranges of about the same length, w <= 0 meaning Workers,
+// and calls f(chunk, lo, hi) on each in its own goroutine. It returns the number of chunks once all calls
+// have returned, so that the results of the chunks can be indexed by chunk and combined
+func Chunks(n int, w int, f func(chunk, lo, hi int)) int {
+	if n <= 0 {
+		return 0
+	}
+
+	w = workers(n, w)
+	var wg sync.WaitGroup
+	wg.Add(w)
+	for k := 0; k < w; k++ {
+		lo, hi := k*n/w, (k+1)*n/w
+		go func(k, lo, hi int) {
+			defer wg.Done()
+			f(k, lo, hi)
+		}(k, lo, hi)
+	}
+	wg.Wait()
+
+	return w
+}
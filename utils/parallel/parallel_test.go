@@ -0,0 +1,50 @@
+package parallel
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFor(t *testing.T) {
+	for _, w := range []int{0, 1, 3, 100} {
+		const n = 50
+		var calls [n]int32
+		For(n, w, func(i int) {
+			atomic.AddInt32(&calls[i], 1)
+		})
+		for i := range calls {
+			assert.Equal(t, int32(1), calls[i], "workers %d, index %d", w, i)
+		}
+	}
+
+	For(0, 4, func(i int) { t.Error("no iterations") })
+}
+
+func TestChunks(t *testing.T) {
+	for _, test := range []struct {
+		n, w, chunks int
+	}{
+		{10, 3, 3},
+		{10, 1, 1},
+		{2, 8, 2},
+		{0, 4, 0},
+	} {
+		var covered = make([]int32, test.n)
+		var calls int32
+		chunks := Chunks(test.n, test.w, func(chunk, lo, hi int) {
+			atomic.AddInt32(&calls, 1)
+			assert.True(t, lo < hi, "non-empty chunk")
+			for i := lo; i < hi; i++ {
+				atomic.AddInt32(&covered[i], 1)
+			}
+		})
+
+		assert.Equal(t, test.chunks, chunks, "n %d, w %d", test.n, test.w)
+		assert.Equal(t, int32(test.chunks), calls, "one call per chunk")
+		for i := range covered {
+			assert.Equal(t, int32(1), covered[i], "index %d in exactly one chunk", i)
+		}
+	}
+}
@@ -6,20 +6,20 @@ import (
 	"fmt"
 	"math/big"
 
-	"github.com/Nik-U/pbc"
 	"github.com/nikamn/BC-SSE/utils/conv"
-	"github.com/nikamn/BC-SSE/utils/ecparam"
+	"github.com/nikamn/BC-SSE/utils/curve"
+	curvepbc "github.com/nikamn/BC-SSE/utils/curve/pbc"
 	"github.com/nikamn/BC-SSE/utils/polyring"
 )
 
-// Curve exported from ecparam
-var Curve = ecparam.PBC256
+// Curve is the group of the commitments, the Type A curve of ecparam PBC256 by default
+var Curve curve.Curve = curvepbc.PBC256
 
 // PolyCommit struct
 // a commitment to a polynomial {a0, a1, ..., at} is g^at
 // ai are from the multiplicative group of integers modulo p
 type PolyCommit struct {
-	c []*pbc.Element
+	c []curve.Element
 }
 
 // GobEncode returns an encoded commitment to bytes
@@ -30,8 +30,8 @@ func (comm PolyCommit) GobEncode() ([]byte, error) {
 	binary := make([][]byte, len(comm.c))
 
 	for i := range binary {
-		binary[i] = comm.c[i].CompressedBytes()
-		if comm.c[i].Is0() {
+		binary[i] = comm.c[i].Bytes()
+		if comm.c[i].Is1() {
 			binary[i][0] = 0xff
 		}
 	}
@@ -54,15 +54,15 @@ func (comm *PolyCommit) GobDecode(buf []byte) error {
 		return err
 	}
 
-	comm.c = make([]*pbc.Element, len(binary))
+	comm.c = make([]curve.Element, len(binary))
 
 	for i := range binary {
-		comm.c[i] = Curve.Pairing.NewG1()
+		comm.c[i] = Curve.NewG1()
 		// handling infinity point specially
-		if binary[i][0] == 0xff {
-			comm.c[i].Set0()
-		} else {
-			comm.c[i].SetCompressedBytes(binary[i])
+		if binary[i][0] != 0xff {
+			if err := comm.c[i].SetBytes(binary[i]); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -84,6 +84,16 @@ func (comm PolyCommit) Equals(other PolyCommit) bool {
 	return true
 }
 
+// GetPtrToConstant returns a pointer to the commitment g^a0 to the constant term
+func (comm PolyCommit) GetPtrToConstant() curve.Element {
+	return comm.c[0]
+}
+
+// GetDegree returns the degree of the committed polynomial
+func (comm PolyCommit) GetDegree() int {
+	return len(comm.c) - 1
+}
+
 // Bytes encodes a commitment to binary bytes using GobEncode()
 func (comm PolyCommit) Bytes() []byte {
 	binary, err := comm.GobEncode()
@@ -114,13 +124,14 @@ func NewPolyCommit(polynomial polyring.Polynomial) PolyCommit {
 	allCoeff := polynomial.GetAllCoefficients()
 
 	comm := PolyCommit{
-		c: make([]*pbc.Element, len(allCoeff)),
+		c: make([]curve.Element, len(allCoeff)),
 	}
 
+	g := Curve.G1()
 	for i, coeff := range allCoeff {
-		comm.c[i] = Curve.Pairing.NewG1()
+		comm.c[i] = Curve.NewG1()
 		pow := conv.GmpInt2BigInt(coeff)
-		Curve.G.PowBig(comm.c[i], pow)
+		comm.c[i].PowBig(g, pow)
 	}
 
 	return comm
@@ -131,12 +142,13 @@ func (comm PolyCommit) Verify(poly polyring.Polynomial) bool {
 	coeffs := poly.GetAllCoefficients()
 
 	commCheck := PolyCommit{
-		c: make([]*pbc.Element, len(coeffs)),
+		c: make([]curve.Element, len(coeffs)),
 	}
 
+	g := Curve.G1()
 	for i, coeff := range coeffs {
-		commCheck.c[i] = Curve.Pairing.NewG1()
-		Curve.G.PowBig(commCheck.c[i], conv.GmpInt2BigInt(coeff))
+		commCheck.c[i] = Curve.NewG1()
+		commCheck.c[i].PowBig(g, conv.GmpInt2BigInt(coeff))
 		if !commCheck.c[i].Equals(comm.c[i]) {
 			return false
 		}
@@ -147,15 +159,16 @@ func (comm PolyCommit) Verify(poly polyring.Polynomial) bool {
 
 // VerifyEval verifies a commitment using (x,y)
 func (comm PolyCommit) VerifyEval(x *big.Int, y *big.Int) bool {
-	gYRef := Curve.Pairing.NewG1()
-	Curve.G.PowBig(gYRef, y)
+	gYRef := Curve.NewG1()
+	gYRef.PowBig(Curve.G1(), y)
 
 	xx := big.NewInt(1)
+	order := Curve.Order()
 
-	gPx := Curve.Pairing.NewG1()
+	gPx := Curve.NewG1()
 	gPx.Set1()
 
-	tmp := Curve.Pairing.NewG1()
+	tmp := Curve.NewG1()
 	for i := range comm.c {
 		// tmp = g^ai^{x^i}
 		tmp.PowBig(comm.c[i], xx)
@@ -163,7 +176,7 @@ func (comm PolyCommit) VerifyEval(x *big.Int, y *big.Int) bool {
 		gPx.Mul(tmp, gPx)
 
 		xx.Mul(xx, x)
-		xx.Mod(xx, Curve.Nbig)
+		xx.Mod(xx, order)
 	}
 
 	return gPx.Equals(gYRef)
@@ -176,11 +189,11 @@ func AdditiveHomomorphism(commQ, commR PolyCommit) PolyCommit {
 	}
 
 	comm := PolyCommit{
-		c: make([]*pbc.Element, len(commQ.c)),
+		c: make([]curve.Element, len(commQ.c)),
 	}
 
 	for i := range comm.c {
-		comm.c[i] = Curve.Pairing.NewG1()
+		comm.c[i] = Curve.NewG1()
 		comm.c[i].Mul(commQ.c[i], commR.c[i])
 	}
 
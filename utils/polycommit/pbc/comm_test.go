@@ -13,11 +13,13 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+var order = conv.BigInt2GmpInt(Curve.Order())
+
 var poly = polyring.FromVec(0, 2, 3, 4, 5, 6)
 var poly2 = polyring.FromVec(11, 12, 13, 14, 15, 16)
 
 func TestParams_String(t *testing.T) {
-	println(Curve.Params.String())
+	println(Curve.Name())
 }
 
 func TestCommit(t *testing.T) {
@@ -28,6 +30,23 @@ func TestCommit(t *testing.T) {
 func TestPolyCommit_Verify(t *testing.T) {
 	comm := NewPolyCommit(poly)
 	assert.True(t, comm.Verify(poly))
+	assert.False(t, comm.Verify(poly2), "another polynomial")
+}
+
+// committing must compute g^ai and leave the generator unchanged
+func TestNewPolyCommit_Generator(t *testing.T) {
+	g := Curve.NewG1().Set(Curve.G1())
+	comm := NewPolyCommit(poly2)
+	assert.True(t, g.Equals(Curve.G1()), "generator unchanged")
+
+	for i := 0; i <= poly2.GetDegree(); i++ {
+		ai, _ := poly2.GetCoefficient(i)
+		expected := Curve.NewG1().PowBig(g, conv.GmpInt2BigInt(&ai))
+		assert.True(t, expected.Equals(comm.c[i]), "g^a%d", i)
+	}
+
+	// the commitments are all different, not a degenerate element
+	assert.False(t, comm.c[0].Equals(comm.c[1]), "distinct commitments")
 }
 
 func TestPolyCommit_Gob(t *testing.T) {
@@ -55,12 +74,15 @@ func TestPolyCommit_Gob(t *testing.T) {
 func TestPolyCommit_VerifyEval(t *testing.T) {
 	x := gmp.NewInt(15623523536)
 	y := gmp.NewInt(0)
-	poly.EvalMod(x, Curve.Ngmp, y)
+	poly.EvalMod(x, order, y)
 
 	comm := NewPolyCommit(poly)
 	r := comm.VerifyEval(conv.GmpInt2BigInt(x), conv.GmpInt2BigInt(y))
 
 	assert.True(t, r)
+
+	y.Add(y, gmp.NewInt(1))
+	assert.False(t, comm.VerifyEval(conv.GmpInt2BigInt(x), conv.GmpInt2BigInt(y)), "wrong evaluation")
 }
 
 func TestAdditiveHomomorphism(t *testing.T) {
@@ -69,7 +91,7 @@ func TestAdditiveHomomorphism(t *testing.T) {
 
 	poly3 := polyring.Polynomial{}
 	poly3.Add(poly, poly2)
-	poly3.Mod(Curve.Ngmp)
+	poly3.Mod(order)
 
 	comm3 := AdditiveHomomorphism(com1, com2)
 	assert.True(t, comm3.Verify(poly3))
@@ -80,15 +102,15 @@ const bigPolyDegree = 100
 var rnd = rand.New(rand.NewSource(time.Now().UTC().UnixNano()))
 
 func BenchmarkVerifyEval(b *testing.B) {
-	poly100, err := polyring.NewRand(bigPolyDegree, rnd, Curve.Ngmp)
+	poly100, err := polyring.NewRand(bigPolyDegree, rnd, order)
 	assert.Nil(b, err)
 
 	// x is a random point
 	x := new(gmp.Int)
-	x.Rand(rnd, Curve.Ngmp)
+	x.Rand(rnd, order)
 
 	y := gmp.NewInt(0)
-	poly100.EvalMod(x, Curve.Ngmp, y)
+	poly100.EvalMod(x, order, y)
 
 	comm := NewPolyCommit(poly100)
 
@@ -1,15 +1,25 @@
 package polypoint
 
 import (
-	"github.com/Nik-U/pbc"
+	"encoding/json"
+	"errors"
+
 	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/curve"
 )
 
 // PolyPoint struct
 type PolyPoint struct {
 	X       int32
 	Y       *gmp.Int
-	PolyWit *pbc.Element
+	PolyWit curve.Element
+}
+
+// pointJSON is the JSON encoding of a PolyPoint, with the compressed witness in hex
+type pointJSON struct {
+	X       int32    `json:"x"`
+	Y       *gmp.Int `json:"y"`
+	Witness string   `json:"witness,omitempty"`
 }
 
 // NewZeroPoint returns a (0,0,nil) polypoint
@@ -22,10 +32,49 @@ func NewZeroPoint() *PolyPoint {
 }
 
 // NewPoint returns a polypoint (x,y,w)
-func NewPoint(x int32, y *gmp.Int, w *pbc.Element) *PolyPoint {
+func NewPoint(x int32, y *gmp.Int, w curve.Element) *PolyPoint {
 	return &PolyPoint{
 		X:       x,
 		Y:       y,
 		PolyWit: w,
 	}
 }
+
+// MarshalJSON returns the point as a JSON object, with the witness omitted if it is nil
+func (pt *PolyPoint) MarshalJSON() ([]byte, error) {
+	enc := pointJSON{X: pt.X, Y: pt.Y}
+	if pt.PolyWit != nil {
+		enc.Witness = curve.Hex(pt.PolyWit)
+	}
+	return json.Marshal(enc)
+}
+
+// UnmarshalJSON sets the point to the one encoded by MarshalJSON.
+// As the point does not know its curve, the witness is decoded into PolyWit, which must be
+// an element of G1 of that curve; PolyWit is set to nil if the encoding has no witness
+func (pt *PolyPoint) UnmarshalJSON(data []byte) error {
+	var enc pointJSON
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return err
+	}
+
+	if enc.Y == nil {
+		return errors.New("missing y")
+	}
+
+	if enc.Witness == "" {
+		pt.PolyWit = nil
+	} else {
+		if pt.PolyWit == nil {
+			return errors.New("no element to decode the witness into")
+		}
+		if err := curve.SetHex(pt.PolyWit, enc.Witness); err != nil {
+			return err
+		}
+	}
+
+	pt.X = enc.X
+	pt.Y = enc.Y
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package polypoint
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ncw/gmp"
+	curvebn254 "github.com/nikamn/BC-SSE/utils/curve/bn254"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolyPoint_JSON(test *testing.T) {
+	cv := curvebn254.BN254
+	w := cv.NewG1().PowBig(cv.G1(), big.NewInt(7))
+
+	pt := NewPoint(3, gmp.NewInt(42), w)
+	data, err := json.Marshal(pt)
+	assert.Nil(test, err, "Marshal")
+
+	decoded := NewPoint(0, gmp.NewInt(0), cv.NewG1())
+	assert.Nil(test, json.Unmarshal(data, decoded), "Unmarshal")
+	assert.Equal(test, int32(3), decoded.X)
+	assert.Equal(test, 0, decoded.Y.Cmp(gmp.NewInt(42)))
+	assert.True(test, decoded.PolyWit.Equals(w), "witness")
+
+	// the witness needs an element to be decoded into
+	assert.NotNil(test, json.Unmarshal(data, NewZeroPoint()), "no element")
+
+	// without a witness
+	data, err = json.Marshal(NewPoint(3, gmp.NewInt(42), nil))
+	assert.Nil(test, err, "Marshal")
+	assert.NotContains(test, string(data), "witness")
+
+	decoded = NewPoint(0, gmp.NewInt(0), cv.NewG1())
+	assert.Nil(test, json.Unmarshal(data, decoded), "Unmarshal")
+	assert.Nil(test, decoded.PolyWit, "no witness")
+
+	assert.NotNil(test, json.Unmarshal([]byte(`{"x":3,"y":42,"witness":"zz"}`), NewPoint(0, nil, cv.NewG1())), "invalid witness")
+	assert.NotNil(test, json.Unmarshal([]byte(`{"x":3}`), NewZeroPoint()), "missing y")
+}
@@ -0,0 +1,122 @@
+package polyring
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/ncw/gmp"
+)
+
+// BivariatePolynomial struct
+// a symmetric bivariate polynomial B(x,y) = sum coeff[i][j] x^i y^j of degree t in each variable,
+// where coeff[i][j] == coeff[j][i]. Row i is the univariate polynomial B(i,y) given to node i.
+type BivariatePolynomial struct {
+	coeff [][]*gmp.Int
+}
+
+// NewBivariate returns a bivariate polynomial B(x,y) = 0 of degree degree in each variable
+func NewBivariate(degree int) (BivariatePolynomial, error) {
+	if degree < 0 {
+		err := fmt.Errorf("degree must be non-negative, got %d", degree)
+		return BivariatePolynomial{}, err
+	}
+
+	coeff := make([][]*gmp.Int, degree+1)
+	for i := range coeff {
+		coeff[i] = make([]*gmp.Int, degree+1)
+		VecInit(coeff[i])
+	}
+
+	return BivariatePolynomial{coeff}, nil
+}
+
+// NewBivariateRand returns a random symmetric bivariate polynomial with B(0,0) = secret
+// coefficients other than the constant are pseudo-random numbers in [0, n)
+func NewBivariateRand(degree int, rand *rand.Rand, n *gmp.Int, secret *gmp.Int) (BivariatePolynomial, error) {
+	bivar, err := NewBivariate(degree)
+	if err != nil {
+		return BivariatePolynomial{}, err
+	}
+
+	// only the upper triangle is sampled, the lower one is mirrored
+	for i := 0; i <= degree; i++ {
+		for j := i; j <= degree; j++ {
+			bivar.coeff[i][j].Rand(rand, n)
+			bivar.coeff[j][i].Set(bivar.coeff[i][j])
+		}
+	}
+
+	bivar.coeff[0][0].Set(secret)
+
+	return bivar, nil
+}
+
+// GetDegree returns the degree in each variable
+func (bivar BivariatePolynomial) GetDegree() int {
+	return len(bivar.coeff) - 1
+}
+
+// GetCoefficient returns coeff[i][j]
+func (bivar BivariatePolynomial) GetCoefficient(i, j int) (gmp.Int, error) {
+	if i < 0 || i >= len(bivar.coeff) || j < 0 || j >= len(bivar.coeff) {
+		return *gmp.NewInt(0), errors.New("out of boundary")
+	}
+
+	return *bivar.coeff[i][j], nil
+}
+
+// SetCoefficientBig sets both coeff[i][j] and coeff[j][i] to ci, keeping the polynomial symmetric
+func (bivar *BivariatePolynomial) SetCoefficientBig(i, j int, ci *gmp.Int) error {
+	if i < 0 || i >= len(bivar.coeff) || j < 0 || j >= len(bivar.coeff) {
+		return errors.New("out of boundary")
+	}
+
+	bivar.coeff[i][j].Set(ci)
+	bivar.coeff[j][i].Set(ci)
+
+	return nil
+}
+
+// GetPtrToConstant returns a pointer to coeff[0][0], i.e. the shared secret B(0,0)
+func (bivar BivariatePolynomial) GetPtrToConstant() *gmp.Int {
+	return bivar.coeff[0][0]
+}
+
+// IsSymmetric returns if B(x,y) == B(y,x)
+func (bivar BivariatePolynomial) IsSymmetric() bool {
+	for i := range bivar.coeff {
+		for j := i + 1; j < len(bivar.coeff); j++ {
+			if bivar.coeff[i][j].Cmp(bivar.coeff[j][i]) != 0 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Project returns the univariate polynomial B(x,y) in y for a fixed x, reduced mod p
+func (bivar BivariatePolynomial) Project(x *gmp.Int, p *gmp.Int) Polynomial {
+	row, err := New(bivar.GetDegree())
+	if err != nil {
+		panic(err.Error())
+	}
+
+	// the coefficient of y^j is sum_i coeff[i][j] x^i, computed by Horner's rule
+	for j := range row.coeff {
+		for i := bivar.GetDegree(); i >= 0; i-- {
+			row.coeff[j].Mul(row.coeff[j], x)
+			row.coeff[j].Add(row.coeff[j], bivar.coeff[i][j])
+			row.coeff[j].Mod(row.coeff[j], p)
+		}
+	}
+
+	return row
+}
+
+// EvalMod sets result to B(x,y) mod p
+func (bivar BivariatePolynomial) EvalMod(x *gmp.Int, y *gmp.Int, p *gmp.Int, result *gmp.Int) {
+	row := bivar.Project(x, p)
+	row.EvalMod(y, p, result)
+}
@@ -0,0 +1,64 @@
+package polyring
+
+import (
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBivariate(t *testing.T) {
+	bivar, err := NewBivariate(5)
+	assert.Nil(t, err, "NewBivariate")
+	assert.Equal(t, 5, bivar.GetDegree())
+	assert.Zero(t, bivar.GetPtrToConstant().CmpInt32(0))
+
+	_, err = NewBivariate(-1)
+	assert.NotNil(t, err, "negative degree")
+}
+
+func TestNewBivariateRand(t *testing.T) {
+	var degree = 10
+	var n = gmp.NewInt(15486511)
+	secret := gmp.NewInt(42)
+
+	bivar, err := NewBivariateRand(degree, randomness, n, secret)
+	assert.Nil(t, err, "NewBivariateRand")
+
+	assert.True(t, bivar.IsSymmetric(), "symmetric")
+	assert.Zero(t, bivar.GetPtrToConstant().Cmp(secret), "B(0,0)")
+
+	err = bivar.SetCoefficientBig(2, 7, gmp.NewInt(3))
+	assert.Nil(t, err)
+	assert.True(t, bivar.IsSymmetric(), "symmetric after set")
+
+	err = bivar.SetCoefficientBig(2, degree+1, gmp.NewInt(3))
+	assert.NotNil(t, err, "out of boundary")
+}
+
+func TestBivariatePolynomial_Project(t *testing.T) {
+	var degree = 7
+	var n = gmp.NewInt(15486511)
+
+	bivar, err := NewBivariateRand(degree, randomness, n, gmp.NewInt(1234))
+	assert.Nil(t, err, "NewBivariateRand")
+
+	xy, yx, row := gmp.NewInt(0), gmp.NewInt(0), gmp.NewInt(0)
+	for i := int64(0); i < 5; i++ {
+		for j := int64(0); j < 5; j++ {
+			x, y := gmp.NewInt(i), gmp.NewInt(j)
+
+			bivar.EvalMod(x, y, n, xy)
+			bivar.EvalMod(y, x, n, yx)
+			bivar.Project(x, n).EvalMod(y, n, row)
+
+			assert.Zero(t, xy.Cmp(yx), "B(i,j) == B(j,i)")
+			assert.Zero(t, xy.Cmp(row), "B(i,y) at j")
+		}
+	}
+
+	// B(0,y) passes through the secret at y = 0
+	zero := bivar.Project(gmp.NewInt(0), n)
+	assert.Zero(t, zero.GetPtrToConstant().CmpInt32(1234))
+	assert.Equal(t, degree, zero.GetCap()-1)
+}
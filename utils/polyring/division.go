@@ -0,0 +1,116 @@
+package polyring
+
+import (
+	"errors"
+)
+
+// DivThreshold is the degree of the quotient from which DivMod switches from the long division
+// to the Newton iteration of DivModNewton. See BenchmarkDivMod to tune it
+var DivThreshold = 16
+
+// truncate returns poly mod x^k
+func truncate(poly Polynomial, k int) Polynomial {
+	res, err := New(max(min(k, len(poly.coeff))-1, 0))
+	if err != nil {
+		panic(err.Error())
+	}
+
+	for i := range res.coeff {
+		if i < len(poly.coeff) {
+			res.coeff[i].Set(poly.coeff[i])
+		}
+	}
+
+	return res
+}
+
+// reverse returns x^d poly(1/x), the coefficients of degree at most d in reverse order
+func reverse(poly Polynomial, d int) Polynomial {
+	res, err := New(d)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	for i := 0; i <= d && i < len(poly.coeff); i++ {
+		res.coeff[d-i].Set(poly.coeff[i])
+	}
+
+	return res
+}
+
+// InverseSeries sets res to the inverse of poly mod x^k, i.e. poly * res = 1 mod (p, x^k).
+// The constant term of poly must be invertible. Each Newton iteration g = g (2 - poly g) doubles the precision,
+// so the complexity is O(M(k)), M being the cost of Mul
+func (f *Field) InverseSeries(res *Polynomial, poly Polynomial, k int) error {
+	if k < 1 {
+		return errors.New("k must be positive")
+	}
+
+	g := NewEmpty()
+	if err := f.Inverse(g.coeff[0], poly.coeff[0]); err != nil {
+		return errors.New("constant term is not invertible")
+	}
+
+	two := NewConstant(2)
+	tmp := NewEmpty()
+	for precision := 1; precision < k; {
+		precision = min(2*precision, k)
+
+		// tmp = 2 - poly g mod x^precision
+		f.Mul(&tmp, truncate(poly, precision), g)
+		tmp = truncate(tmp, precision)
+		f.Sub(&tmp, two, tmp)
+
+		f.Mul(&g, g, tmp)
+		g = truncate(g, precision)
+	}
+
+	res.ResetTo(g)
+
+	return nil
+}
+
+// DivModNewton computes q, r such that a = b*q + r mod p, with deg r < deg b, in O(M(deg a)).
+// The reversed quotient is the reversed a times the inverse series of the reversed b,
+// whose constant term is the leading coefficient of b
+func (f *Field) DivModNewton(a Polynomial, b Polynomial, q, r *Polynomial) error {
+	aRed, bRed := a.DeepCopy(), b.DeepCopy()
+	f.Reduce(&aRed)
+	f.Reduce(&bRed)
+
+	if bRed.IsZero() {
+		return errors.New("divide by zero")
+	}
+
+	m, n := aRed.GetDegree(), bRed.GetDegree()
+	if m < n {
+		q.ResetTo(NewEmpty())
+		r.ResetTo(aRed)
+		return nil
+	}
+
+	inv := NewEmpty()
+	if err := f.InverseSeries(&inv, reverse(bRed, n), m-n+1); err != nil {
+		return err
+	}
+
+	revQ := NewEmpty()
+	f.Mul(&revQ, reverse(aRed, m), inv)
+	quot := reverse(revQ, m-n)
+	f.Reduce(&quot)
+
+	// r = a - b q
+	rem := NewEmpty()
+	f.Mul(&rem, bRed, quot)
+	f.Sub(&rem, aRed, rem)
+
+	q.ResetTo(quot)
+	r.ResetTo(rem)
+
+	return nil
+}
+
+// useNewton returns if the quotient of a by b is large enough for DivModNewton
+func useNewton(a Polynomial, b Polynomial) bool {
+	return a.GetDegree()-b.GetDegree() >= DivThreshold
+}
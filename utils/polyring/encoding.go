@@ -0,0 +1,204 @@
+package polyring
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ncw/gmp"
+)
+
+// The binary encoding of a polynomial over Z_p is
+//
+//	version (1 byte) | width (1 byte) | number of coefficients n (4 bytes, big endian) | n coefficients
+//
+// with the coefficients from degree 0 up, each in width bytes big endian, width being the byte length of p, so that
+// the encoding doesn't reveal the size of the coefficients. The text encoding is "1:" followed by the same
+// coefficients in hex, separated by ';'.
+// Both are canonical: the coefficients are in [0, p), reduce the polynomial mod p first, and the leading
+// coefficient is not zero unless the polynomial is 0
+
+// encodingVersion is the version of the binary and text encodings
+const encodingVersion = 1
+
+// encodingHeader is the length of the header of the binary encoding
+const encodingHeader = 6
+
+// maxWidth is the largest coefficient length in bytes that the encodings support
+const maxWidth = 255
+
+// ByteLen returns the length in bytes of p, that of the elements in the encodings
+func (f *Field) ByteLen() int {
+	return (f.p.BitLen() + 7) / 8
+}
+
+// FieldPolynomial is a polynomial over a field, with the binary and text encodings of the field. It implements
+// encoding.BinaryMarshaler, encoding.BinaryUnmarshaler, encoding.TextMarshaler and encoding.TextUnmarshaler
+type FieldPolynomial struct {
+	f    *Field
+	poly *Polynomial
+}
+
+// Encoding returns poly with the encodings of f. Unmarshaling sets poly
+func (f *Field) Encoding(poly *Polynomial) FieldPolynomial {
+	return FieldPolynomial{f: f, poly: poly}
+}
+
+// width returns the length in bytes of the coefficients in the encodings
+func (fp FieldPolynomial) width() (int, error) {
+	width := fp.f.ByteLen()
+	if width > maxWidth {
+		return 0, fmt.Errorf("coefficients longer than %d bytes", maxWidth)
+	}
+	return width, nil
+}
+
+// checkReduced returns an error if a coefficient of the polynomial is not in [0, p)
+func (fp FieldPolynomial) checkReduced() error {
+	for i := 0; i <= fp.poly.GetDegree(); i++ {
+		if fp.poly.coeff[i].Sign() < 0 || fp.poly.coeff[i].Cmp(fp.f.p) >= 0 {
+			return fmt.Errorf("coefficient %d is not in [0, p)", i)
+		}
+	}
+	return nil
+}
+
+// putCoefficient writes x into buf, right-aligned
+func putCoefficient(buf []byte, x *gmp.Int) {
+	b := x.Bytes()
+	copy(buf[len(buf)-len(b):], b)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (fp FieldPolynomial) MarshalBinary() ([]byte, error) {
+	width, err := fp.width()
+	if err != nil {
+		return nil, err
+	}
+	if err := fp.checkReduced(); err != nil {
+		return nil, err
+	}
+
+	n := fp.poly.GetDegree() + 1
+	data := make([]byte, encodingHeader+n*width)
+	data[0] = encodingVersion
+	data[1] = byte(width)
+	binary.BigEndian.PutUint32(data[2:encodingHeader], uint32(n))
+
+	for i := 0; i < n; i++ {
+		offset := encodingHeader + i*width
+		putCoefficient(data[offset:offset+width], fp.poly.coeff[i])
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (fp FieldPolynomial) UnmarshalBinary(data []byte) error {
+	width, err := fp.width()
+	if err != nil {
+		return err
+	}
+
+	if len(data) < encodingHeader {
+		return errors.New("polynomial encoding too short")
+	}
+
+	if data[0] != encodingVersion {
+		return fmt.Errorf("unknown polynomial encoding version %d", data[0])
+	}
+
+	if int(data[1]) != width {
+		return fmt.Errorf("coefficient width is %d bytes, the field needs %d", data[1], width)
+	}
+
+	// the length is checked before allocating, so that a corrupt n can't exhaust the memory
+	n := binary.BigEndian.Uint32(data[2:encodingHeader])
+	if n == 0 {
+		return errors.New("no coefficients")
+	}
+	if uint64(len(data)-encodingHeader) != uint64(n)*uint64(width) {
+		return fmt.Errorf("%d coefficients of %d bytes need %d bytes, got %d", n, width, uint64(n)*uint64(width), len(data)-encodingHeader)
+	}
+
+	decoded, err := New(int(n) - 1)
+	if err != nil {
+		return err
+	}
+	for i := range decoded.coeff {
+		offset := encodingHeader + i*width
+		decoded.coeff[i].SetBytes(data[offset : offset+width])
+	}
+
+	return fp.set(decoded)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (fp FieldPolynomial) MarshalText() ([]byte, error) {
+	width, err := fp.width()
+	if err != nil {
+		return nil, err
+	}
+	if err := fp.checkReduced(); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, width)
+	coeffHex := make([]string, fp.poly.GetDegree()+1)
+	for i := range coeffHex {
+		for j := range buf {
+			buf[j] = 0
+		}
+		putCoefficient(buf, fp.poly.coeff[i])
+		coeffHex[i] = hex.EncodeToString(buf)
+	}
+
+	return []byte(fmt.Sprintf("%d:%s", encodingVersion, strings.Join(coeffHex, ";"))), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (fp FieldPolynomial) UnmarshalText(text []byte) error {
+	width, err := fp.width()
+	if err != nil {
+		return err
+	}
+
+	prefix := fmt.Sprintf("%d:", encodingVersion)
+	s := string(text)
+	if !strings.HasPrefix(s, prefix) {
+		return errors.New("unknown polynomial encoding version")
+	}
+
+	coeffHex := strings.Split(strings.TrimPrefix(s, prefix), ";")
+	decoded, err := New(len(coeffHex) - 1)
+	if err != nil {
+		return err
+	}
+	for i := range coeffHex {
+		if len(coeffHex[i]) != 2*width {
+			return fmt.Errorf("coefficient %d is not %d bytes", i, width)
+		}
+		b, err := hex.DecodeString(coeffHex[i])
+		if err != nil {
+			return fmt.Errorf("coefficient %d: %v", i, err)
+		}
+		decoded.coeff[i].SetBytes(b)
+	}
+
+	return fp.set(decoded)
+}
+
+// set checks that a decoded polynomial is canonical, then sets the polynomial to it
+func (fp FieldPolynomial) set(decoded Polynomial) error {
+	if len(decoded.coeff) > 1 && decoded.coeff[len(decoded.coeff)-1].Sign() == 0 {
+		return errors.New("leading coefficient is zero")
+	}
+	if err := fp.f.Encoding(&decoded).checkReduced(); err != nil {
+		return err
+	}
+
+	*fp.poly = decoded
+	return nil
+}
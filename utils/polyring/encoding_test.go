@@ -0,0 +1,94 @@
+package polyring
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolynomial_Encoding(t *testing.T) {
+	p := gmp.NewInt(0)
+	p.SetString("57896044618658097711785492504343953926634992332820282019728792006155588075521", 10)
+	f, err := NewField(p)
+	assert.Nil(t, err, "NewField")
+	assert.Equal(t, 32, f.ByteLen())
+
+	random, err := NewRand(20, randomness, p)
+	assert.Nil(t, err, "NewRand")
+
+	for _, poly := range []Polynomial{NewEmpty(), FromVec(5), FromVec(0, 0, 3), FromVec(1, 0, 0, 256, 0), random} {
+		data, err := f.Encoding(&poly).MarshalBinary()
+		assert.Nil(t, err, "MarshalBinary")
+		assert.Equal(t, encodingHeader+32*(poly.GetDegree()+1), len(data), "fixed width")
+		fromBinary := NewEmpty()
+		assert.Nil(t, f.Encoding(&fromBinary).UnmarshalBinary(data), "UnmarshalBinary")
+		assert.True(t, poly.IsSame(fromBinary), "binary %s", poly.String())
+
+		text, err := json.Marshal(f.Encoding(&poly))
+		assert.Nil(t, err, "MarshalText")
+		fromText := NewEmpty()
+		encoding := f.Encoding(&fromText)
+		assert.Nil(t, json.Unmarshal(text, &encoding), "UnmarshalText")
+		assert.True(t, poly.IsSame(fromText), "text %s", poly.String())
+	}
+
+	// the coefficients have the width of p, 2 bytes for 257
+	small, err := NewField(gmp.NewInt(257))
+	assert.Nil(t, err, "NewField")
+	poly := FromVec(1, 0, 0, 256)
+	data, _ := small.Encoding(&poly).MarshalBinary()
+	assert.Equal(t, []byte{1, 2, 0, 0, 0, 4, 0, 1, 0, 0, 0, 0, 1, 0}, data)
+	text, _ := small.Encoding(&poly).MarshalText()
+	assert.Equal(t, "1:0001;0000;0000;0100", string(text))
+
+	for _, bad := range []Polynomial{FromVec(1, -2), FromVec(1, 257)} {
+		_, err = small.Encoding(&bad).MarshalBinary()
+		assert.NotNil(t, err, "MarshalBinary %s", bad.String())
+		_, err = small.Encoding(&bad).MarshalText()
+		assert.NotNil(t, err, "MarshalText %s", bad.String())
+	}
+
+	poly = NewEmpty()
+	for _, bad := range [][]byte{
+		{},
+		{2, 2, 0, 0, 0, 1, 0, 5},          // version
+		{1, 1, 0, 0, 0, 1, 5},             // width
+		{1, 2, 0, 0, 0, 0},                // no coefficients
+		{1, 2, 0, 0, 0, 2, 0, 5},          // truncated
+		{1, 2, 0, 0, 0, 1, 0, 5, 6},       // trailing bytes
+		{1, 2, 0, 0, 0, 2, 0, 5, 0, 0},    // leading zero
+		{1, 2, 0xff, 0, 0, 0, 0, 5},       // huge count
+		{1, 2, 0, 0, 0, 1, 1, 1},          // p
+		{1, 2, 0, 0, 0, 2, 0, 5, 0xff, 0}, // above p
+	} {
+		assert.NotNil(t, small.Encoding(&poly).UnmarshalBinary(bad), "%v", bad)
+	}
+
+	for _, bad := range []string{"", "0005;0001", "2:0005", "1:", "1:0005;01", "1:0005;000g", "1:0005;0000", "1:05", "1:0101"} {
+		assert.NotNil(t, small.Encoding(&poly).UnmarshalText([]byte(bad)), "%q", bad)
+	}
+	assert.True(t, poly.IsZero(), "unchanged by errors")
+
+	// an encoding is only read back in the field it was written for
+	data, _ = f.Encoding(&random).MarshalBinary()
+	assert.NotNil(t, small.Encoding(&poly).UnmarshalBinary(data), "another field")
+}
+
+func TestFromString(t *testing.T) {
+	poly, err := FromString(FromVec(3, 0, 0, 7).String())
+	assert.Nil(t, err, "FromString")
+	assert.True(t, poly.IsSame(FromVec(3, 0, 0, 7)), "interior zeros")
+	assert.Equal(t, 4, poly.GetCap(), "interior zeros kept")
+
+	poly, err = FromString("3;0;-2;0;0")
+	assert.Nil(t, err, "FromString")
+	assert.True(t, poly.IsSame(FromVec(3, 0, -2)))
+	assert.Equal(t, 3, poly.GetCap(), "leading zeros dropped")
+
+	for _, bad := range []string{"", "1;;2", "1;x", "0x10"} {
+		_, err = FromString(bad)
+		assert.NotNil(t, err, "%q", bad)
+	}
+}
@@ -0,0 +1,248 @@
+package polyring
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+
+	"github.com/ncw/gmp"
+)
+
+// Field is the prime field Z_p. Unlike the methods of Polynomial, which work over the integers and leave
+// reducing mod p to the caller, its operations reduce every result mod p and divide with modular inverses
+type Field struct {
+	p *gmp.Int
+
+	// 2^twoAdicity is the largest power of two dividing p-1, root is a primitive 2^twoAdicity-th root of unity
+	twoAdicity int
+	root       *gmp.Int
+}
+
+// NewField returns the field Z_p, p must be prime
+func NewField(p *gmp.Int) (*Field, error) {
+	if p == nil || p.CmpInt32(2) < 0 || !p.ProbablyPrime(20) {
+		return nil, errors.New("modulus must be prime")
+	}
+
+	f := &Field{p: new(gmp.Int).Set(p)}
+
+	order := gmp.NewInt(0)
+	order.Sub(p, gmp.NewInt(1))
+	for order.CmpInt32(0) != 0 && order.Bit(f.twoAdicity) == 0 {
+		f.twoAdicity++
+	}
+
+	// n must fit in an int
+	if f.twoAdicity > 62 {
+		f.twoAdicity = 62
+	}
+
+	root, err := RootOfUnity(1<<uint(f.twoAdicity), f.p)
+	if err != nil {
+		return nil, err
+	}
+	f.root = root
+
+	return f, nil
+}
+
+// maxCachedFields bounds the number of moduli whose Field GetField keeps
+const maxCachedFields = 16
+
+// fieldCache maps the moduli, in decimal, to the results of NewField
+var fieldCache = struct {
+	sync.Mutex
+	fields map[string]*Field
+	errs   map[string]error
+}{fields: make(map[string]*Field), errs: make(map[string]error)}
+
+// GetField returns NewField(p), from a cache of the moduli seen before, so that functions taking a modulus
+// don't test its primality and search a root of unity on every call. A Field is only read by its methods,
+// so the cached one may be shared by several goroutines
+func GetField(p *gmp.Int) (*Field, error) {
+	if p == nil {
+		return NewField(p)
+	}
+	key := p.String()
+
+	fieldCache.Lock()
+	defer fieldCache.Unlock()
+
+	if f, ok := fieldCache.fields[key]; ok {
+		return f, nil
+	}
+	if err, ok := fieldCache.errs[key]; ok {
+		return nil, err
+	}
+
+	f, err := NewField(p)
+	if len(fieldCache.fields)+len(fieldCache.errs) < maxCachedFields {
+		if err != nil {
+			fieldCache.errs[key] = err
+		} else {
+			fieldCache.fields[key] = f
+		}
+	}
+
+	return f, err
+}
+
+// Modulus returns p
+func (f *Field) Modulus() *gmp.Int {
+	return new(gmp.Int).Set(f.p)
+}
+
+// Reduce sets poly to poly mod p, with coefficients in [0, p)
+func (f *Field) Reduce(poly *Polynomial) {
+	poly.Mod(f.p)
+	poly.shrinkToSize()
+}
+
+// Add sets res to op1 + op2 mod p
+func (f *Field) Add(res *Polynomial, op1 Polynomial, op2 Polynomial) {
+	sum := NewEmpty()
+	sum.Add(op1, op2)
+	f.Reduce(&sum)
+	res.ResetTo(sum)
+}
+
+// Sub sets res to op1 - op2 mod p
+func (f *Field) Sub(res *Polynomial, op1 Polynomial, op2 Polynomial) {
+	diff := NewEmpty()
+	diff.Sub(op1, op2)
+	f.Reduce(&diff)
+	res.ResetTo(diff)
+}
+
+// Mul sets res to op1 * op2 mod p. Above NTTThreshold it uses MulNTT if p-1 has enough factors of two
+func (f *Field) Mul(res *Polynomial, op1 Polynomial, op2 Polynomial) {
+	deg1, deg2 := op1.GetDegree(), op2.GetDegree()
+	if min(deg1, deg2) >= NTTThreshold && f.SupportsNTT(deg1+deg2+1) {
+		if err := f.MulNTT(res, op1, op2); err != nil {
+			panic(err.Error())
+		}
+		return
+	}
+
+	prod := NewEmpty()
+	prod.Mul(op1, op2)
+	f.Reduce(&prod)
+	res.ResetTo(prod)
+}
+
+// MulScalar sets res to op * k mod p
+func (f *Field) MulScalar(res *Polynomial, op Polynomial, k *gmp.Int) {
+	prod := op.DeepCopy()
+	for i := range prod.coeff {
+		prod.coeff[i].Mul(prod.coeff[i], k)
+	}
+	f.Reduce(&prod)
+	res.ResetTo(prod)
+}
+
+// AddMul sets res to res + op * k mod p
+func (f *Field) AddMul(res *Polynomial, op Polynomial, k *gmp.Int) {
+	prod := NewEmpty()
+	f.MulScalar(&prod, op, k)
+	f.Add(res, *res, prod)
+}
+
+// Inverse sets res to 1/x mod p, x must not be a multiple of p
+func (f *Field) Inverse(res *gmp.Int, x *gmp.Int) error {
+	reduced := gmp.NewInt(0)
+	reduced.Mod(x, f.p)
+	if reduced.CmpInt32(0) == 0 {
+		return errors.New("divide by zero")
+	}
+
+	res.ModInverse(reduced, f.p)
+
+	return nil
+}
+
+// DivMod computes q, r such that a = b*q + r mod p, with deg r < deg b
+func (f *Field) DivMod(a Polynomial, b Polynomial, q, r *Polynomial) error {
+	// a and b are reduced first, so that a leading coefficient that is a multiple of p is not taken for non-zero
+	aRed, bRed := a.DeepCopy(), b.DeepCopy()
+	f.Reduce(&aRed)
+	f.Reduce(&bRed)
+
+	if useNewton(aRed, bRed) {
+		return f.DivModNewton(aRed, bRed, q, r)
+	}
+
+	if err := divModNaive(aRed, bRed, f.p, q, r); err != nil {
+		return err
+	}
+
+	f.Reduce(q)
+	f.Reduce(r)
+
+	return nil
+}
+
+// Div sets res to op1 / op2 mod p, op2 must divide op1
+func (f *Field) Div(res *Polynomial, op1 Polynomial, op2 Polynomial) error {
+	q, r := NewEmpty(), NewEmpty()
+	if err := f.DivMod(op1, op2, &q, &r); err != nil {
+		return err
+	}
+
+	if !r.IsZero() {
+		return errors.New("op2 does not divide op1")
+	}
+
+	res.ResetTo(q)
+
+	return nil
+}
+
+// Div2 sets res to the quotient of op1 by op2 mod p, dropping the remainder. op2 must be of degree 1
+// Complexity is O(deg1)
+func (f *Field) Div2(res *Polynomial, op1 Polynomial, op2 Polynomial) error {
+	b := op2.DeepCopy()
+	f.Reduce(&b)
+	if b.GetDegree() != 1 {
+		return errors.New("op2 must be of degree 1")
+	}
+
+	lcInv := gmp.NewInt(0)
+	if err := f.Inverse(lcInv, b.coeff[1]); err != nil {
+		return err
+	}
+
+	inter := op1.DeepCopy()
+	f.Reduce(&inter)
+	deg1 := inter.GetDegree()
+
+	quot, err := New(max(deg1-1, 0))
+	if err != nil {
+		return err
+	}
+
+	// synthetic division: the leading term of inter is cancelled by quot[i-1] x^{i-1} op2
+	tmp := gmp.NewInt(0)
+	for i := deg1; i > 0; i-- {
+		quot.coeff[i-1].Mul(inter.coeff[i], lcInv)
+		quot.coeff[i-1].Mod(quot.coeff[i-1], f.p)
+
+		tmp.Mul(quot.coeff[i-1], b.coeff[0])
+		inter.coeff[i-1].Sub(inter.coeff[i-1], tmp)
+		inter.coeff[i-1].Mod(inter.coeff[i-1], f.p)
+	}
+
+	f.Reduce(&quot)
+	res.ResetTo(quot)
+
+	return nil
+}
+
+// Eval sets res to poly(x) mod p
+func (f *Field) Eval(res *gmp.Int, poly Polynomial, x *gmp.Int) {
+	poly.EvalMod(x, f.p, res)
+}
+
+// Rand returns a random polynomial of the specified degree with coefficients in [0, p)
+func (f *Field) Rand(degree int, rnd *rand.Rand) (Polynomial, error) {
+	return NewRand(degree, rnd, f.p)
+}
@@ -0,0 +1,136 @@
+package polyring
+
+import (
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewField(t *testing.T) {
+	f, err := NewField(gmp.NewInt(11))
+	assert.Nil(t, err, "NewField")
+	assert.Equal(t, 0, f.Modulus().Cmp(gmp.NewInt(11)))
+
+	for _, p := range []int64{0, 1, 15} {
+		_, err = NewField(gmp.NewInt(p))
+		assert.NotNil(t, err, "p = %d", p)
+	}
+}
+
+func TestGetField(t *testing.T) {
+	f, err := GetField(gmp.NewInt(13))
+	assert.Nil(t, err, "GetField")
+	assert.Equal(t, 0, f.Modulus().Cmp(gmp.NewInt(13)))
+
+	again, err := GetField(gmp.NewInt(13))
+	assert.Nil(t, err, "GetField")
+	assert.True(t, f == again, "cached")
+
+	for _, p := range []int64{-13, 15} {
+		_, err = GetField(gmp.NewInt(p))
+		assert.NotNil(t, err, "p = %d", p)
+		_, err = GetField(gmp.NewInt(p))
+		assert.NotNil(t, err, "p = %d cached", p)
+	}
+}
+
+func TestField_Arithmetic(t *testing.T) {
+	f, err := NewField(gmp.NewInt(11))
+	assert.Nil(t, err, "NewField")
+
+	a := FromVec(3, 7, 5) // 3 + 7x + 5x^2
+	b := FromVec(9, 6)    // 9 + 6x
+
+	res := NewEmpty()
+	f.Add(&res, a, b)
+	assert.True(t, res.IsSame(FromVec(1, 2, 5)), "Add")
+
+	f.Sub(&res, b, a)
+	assert.True(t, res.IsSame(FromVec(6, 10, 6)), "Sub")
+
+	// the leading coefficients cancel mod p
+	f.Sub(&res, FromVec(1, 2, 12), FromVec(0, 0, 1))
+	assert.Equal(t, 1, res.GetDegree(), "Sub degree")
+
+	// (3 + 7x + 5x^2)(9 + 6x) = 27 + 81x + 87x^2 + 30x^3
+	f.Mul(&res, a, b)
+	assert.True(t, res.IsSame(FromVec(5, 4, 10, 8)), "Mul")
+
+	// the receiver may be an operand
+	f.Mul(&res, res, FromVec(0, 1))
+	assert.True(t, res.IsSame(FromVec(0, 5, 4, 10, 8)), "Mul in place")
+
+	f.MulScalar(&res, a, gmp.NewInt(-1))
+	assert.True(t, res.IsSame(FromVec(8, 4, 6)), "MulScalar")
+
+	res = FromVec(1)
+	f.AddMul(&res, a, gmp.NewInt(2))
+	assert.True(t, res.IsSame(FromVec(7, 3, 10)), "AddMul")
+
+	x := gmp.NewInt(0)
+	assert.Nil(t, f.Inverse(x, gmp.NewInt(-3)), "Inverse")
+	assert.Equal(t, 0, x.Cmp(gmp.NewInt(7)), "Inverse")
+	assert.NotNil(t, f.Inverse(x, gmp.NewInt(22)), "Inverse of 0")
+
+	f.Eval(x, a, gmp.NewInt(2))
+	assert.Equal(t, 0, x.Cmp(gmp.NewInt(4)), "Eval")
+}
+
+func TestField_Div(t *testing.T) {
+	p := gmp.NewInt(0)
+	p.SetString("57896044618658097711785492504343953926634992332820282019728792006155588075521", 10)
+	f, err := NewField(p)
+	assert.Nil(t, err, "NewField")
+
+	a, err := f.Rand(10, randomness)
+	assert.Nil(t, err, "Rand")
+	b, err := f.Rand(4, randomness)
+	assert.Nil(t, err, "Rand")
+
+	q, r := NewEmpty(), NewEmpty()
+	assert.Nil(t, f.DivMod(a, b, &q, &r), "DivMod")
+	assert.Equal(t, 6, q.GetDegree(), "quotient degree")
+	assert.True(t, r.GetDegree() < 4, "remainder degree")
+
+	// a == b*q + r
+	check := NewEmpty()
+	f.Mul(&check, b, q)
+	f.Add(&check, check, r)
+	assert.True(t, check.IsSame(a), "DivMod")
+
+	// exact division
+	prod := NewEmpty()
+	f.Mul(&prod, a, b)
+	assert.Nil(t, f.Div(&q, prod, b), "Div")
+	assert.True(t, q.IsSame(a), "Div")
+
+	f.Add(&prod, prod, NewOne())
+	assert.NotNil(t, f.Div(&q, prod, b), "not divisible")
+	assert.NotNil(t, f.Div(&q, a, FromVec(0)), "divide by zero")
+}
+
+func TestField_Div2(t *testing.T) {
+	f, err := NewField(gmp.NewInt(11))
+	assert.Nil(t, err, "NewField")
+
+	// (3x + 2)(4x^2 + x + 5) = 12x^3 + 11x^2 + 17x + 10 over the integers,
+	// which Polynomial.Div2 cannot divide by the non-monic 3x + 2
+	a := FromVec(10, 17, 11, 12)
+	b := FromVec(2, 3)
+
+	q := NewEmpty()
+	assert.Nil(t, f.Div2(&q, a, b), "Div2")
+	assert.True(t, q.IsSame(FromVec(5, 1, 4)), "Div2")
+
+	// the remainder is dropped: (a + 1) / b has the same quotient
+	assert.Nil(t, f.Div2(&q, FromVec(11, 17, 11, 12), b), "Div2")
+	assert.True(t, q.IsSame(FromVec(5, 1, 4)), "Div2 with remainder")
+
+	// a constant divided by a linear polynomial is 0
+	assert.Nil(t, f.Div2(&q, FromVec(7), b), "Div2 constant")
+	assert.True(t, q.IsZero(), "Div2 constant")
+
+	assert.NotNil(t, f.Div2(&q, a, FromVec(2, 11)), "degree 0 mod p")
+	assert.NotNil(t, f.Div2(&q, a, FromVec(2, 3, 1)), "degree 2")
+}
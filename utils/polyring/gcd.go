@@ -0,0 +1,278 @@
+package polyring
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+
+	"github.com/ncw/gmp"
+)
+
+// GCDThreshold is the degree from which the GCD computations use HalfGCD instead of the Euclidean algorithm
+var GCDThreshold = 32
+
+// PolyMatrix is a 2x2 matrix of polynomials, acting on pairs of polynomials
+type PolyMatrix [2][2]Polynomial
+
+// identityMatrix returns the identity matrix
+func identityMatrix() PolyMatrix {
+	return PolyMatrix{{NewOne(), NewEmpty()}, {NewEmpty(), NewOne()}}
+}
+
+// quotientMatrix returns [[0, 1], [1, -q]], which maps (a, b) to (b, a - q b)
+func (f *Field) quotientMatrix(q Polynomial) PolyMatrix {
+	negQ := NewEmpty()
+	f.Sub(&negQ, NewEmpty(), q)
+	return PolyMatrix{{NewEmpty(), NewOne()}, {NewOne(), negQ}}
+}
+
+// Apply returns (m00 a + m01 b, m10 a + m11 b) mod p
+func (f *Field) Apply(m PolyMatrix, a, b Polynomial) (Polynomial, Polynomial) {
+	var res [2]Polynomial
+	tmp := NewEmpty()
+	for i := range res {
+		res[i] = NewEmpty()
+		f.Mul(&res[i], m[i][0], a)
+		f.Mul(&tmp, m[i][1], b)
+		f.Add(&res[i], res[i], tmp)
+	}
+	return res[0], res[1]
+}
+
+// MulMatrix returns m1 m2 mod p
+func (f *Field) MulMatrix(m1, m2 PolyMatrix) PolyMatrix {
+	var res PolyMatrix
+	tmp := NewEmpty()
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			res[i][j] = NewEmpty()
+			f.Mul(&res[i][j], m1[i][0], m2[0][j])
+			f.Mul(&tmp, m1[i][1], m2[1][j])
+			f.Add(&res[i][j], res[i][j], tmp)
+		}
+	}
+	return res
+}
+
+// degree returns the degree of poly, -1 for the zero polynomial
+func degree(poly Polynomial) int {
+	if poly.IsZero() {
+		return -1
+	}
+	return poly.GetDegree()
+}
+
+// shiftDown returns poly div x^k
+func shiftDown(poly Polynomial, k int) Polynomial {
+	deg := poly.GetDegree()
+	if k > deg {
+		return NewEmpty()
+	}
+
+	res, err := New(deg - k)
+	if err != nil {
+		panic(err.Error())
+	}
+	for i := range res.coeff {
+		res.coeff[i].Set(poly.coeff[i+k])
+	}
+
+	return res
+}
+
+// HalfGCD returns the matrix M of the first steps of the Euclidean algorithm on (a, b), deg a > deg b,
+// such that M (a, b) = (c, d) with deg c >= ceil(deg a / 2) > deg d. It only looks at the high halves
+// of the polynomials, so that the GCD takes O(M(n) log n) instead of O(n^2)
+func (f *Field) HalfGCD(a, b Polynomial) PolyMatrix {
+	m := (degree(a) + 1) / 2
+	if degree(b) < m {
+		return identityMatrix()
+	}
+
+	// the quotients of the high halves are those of a and b as long as the degrees stay above m
+	r := f.HalfGCD(shiftDown(a, m), shiftDown(b, m))
+	c, d := f.Apply(r, a, b)
+	if degree(d) < m {
+		return r
+	}
+
+	q, rem := NewEmpty(), NewEmpty()
+	if err := f.DivMod(c, d, &q, &rem); err != nil {
+		panic(err.Error())
+	}
+	r = f.MulMatrix(f.quotientMatrix(q), r)
+
+	// continue on (d, rem) with the high parts above 2m - deg d
+	k := 2*m - degree(d)
+	if k < 0 {
+		k = 0
+	}
+	s := f.HalfGCD(shiftDown(d, k), shiftDown(rem, k))
+
+	return f.MulMatrix(s, r)
+}
+
+// gcdMatrix returns the matrix M with M (a, b) = (g, 0), g being a gcd of a and b, not necessarily monic
+func (f *Field) gcdMatrix(a, b Polynomial) (PolyMatrix, Polynomial) {
+	f.Reduce(&a)
+	f.Reduce(&b)
+
+	m := identityMatrix()
+	if degree(a) < degree(b) {
+		m = PolyMatrix{{NewEmpty(), NewOne()}, {NewOne(), NewEmpty()}}
+		a, b = b, a
+	}
+
+	for !b.IsZero() {
+		if degree(a) > degree(b) && degree(a) >= GCDThreshold {
+			h := f.HalfGCD(a, b)
+			m = f.MulMatrix(h, m)
+			a, b = f.Apply(h, a, b)
+			if b.IsZero() {
+				break
+			}
+		}
+
+		// a Euclidean step
+		q, rem := NewEmpty(), NewEmpty()
+		if err := f.DivMod(a, b, &q, &rem); err != nil {
+			panic(err.Error())
+		}
+		m = f.MulMatrix(f.quotientMatrix(q), m)
+		a, b = b, rem
+	}
+
+	return m, a
+}
+
+// monic returns the inverse of the leading coefficient of poly, 1 for the zero polynomial
+func (f *Field) monic(poly Polynomial) *gmp.Int {
+	lcInv := gmp.NewInt(1)
+	if !poly.IsZero() {
+		f.Inverse(lcInv, poly.coeff[poly.GetDegree()])
+	}
+	return lcInv
+}
+
+// GCD returns the monic greatest common divisor of a and b mod p, or 0 if both are 0
+func (f *Field) GCD(a, b Polynomial) Polynomial {
+	_, g := f.gcdMatrix(a.DeepCopy(), b.DeepCopy())
+
+	f.MulScalar(&g, g, f.monic(g))
+
+	return g
+}
+
+// ExtendedGCD returns the monic g = GCD(a, b) and the Bezout coefficients s, t such that s a + t b = g mod p
+func (f *Field) ExtendedGCD(a, b Polynomial) (g, s, t Polynomial) {
+	m, g := f.gcdMatrix(a.DeepCopy(), b.DeepCopy())
+
+	lcInv := f.monic(g)
+	s, t = NewEmpty(), NewEmpty()
+	f.MulScalar(&g, g, lcInv)
+	f.MulScalar(&s, m[0][0], lcInv)
+	f.MulScalar(&t, m[0][1], lcInv)
+
+	return g, s, t
+}
+
+// ExpMod sets res to base^e mod (p, mod) by square and multiply
+func (f *Field) ExpMod(res *Polynomial, base Polynomial, e *gmp.Int, mod Polynomial) error {
+	if mod.IsZero() {
+		return errors.New("divide by zero")
+	}
+
+	q, acc, sq := NewEmpty(), NewOne(), NewEmpty()
+	if err := f.DivMod(base, mod, &q, &sq); err != nil {
+		return err
+	}
+	if err := f.DivMod(acc, mod, &q, &acc); err != nil {
+		return err
+	}
+
+	for i := 0; i < e.BitLen(); i++ {
+		if e.Bit(i) == 1 {
+			f.Mul(&acc, acc, sq)
+			f.DivMod(acc, mod, &q, &acc)
+		}
+		f.Mul(&sq, sq, sq)
+		f.DivMod(sq, mod, &q, &sq)
+	}
+
+	res.ResetTo(acc)
+
+	return nil
+}
+
+// Roots returns the distinct roots of poly in F_p in increasing order. The roots are those of
+// gcd(poly, x^p - x), the product of x - r over them, which is split by Cantor-Zassenhaus:
+// gcd((x + delta)^((p-1)/2) - 1, g) has about half the roots for a random delta
+func (f *Field) Roots(poly Polynomial, rnd *rand.Rand) ([]*gmp.Int, error) {
+	a := poly.DeepCopy()
+	f.Reduce(&a)
+	if a.IsZero() {
+		return nil, errors.New("every element is a root of 0")
+	}
+
+	// g = gcd(poly, x^p - x)
+	xp := NewEmpty()
+	if err := f.ExpMod(&xp, FromVec(0, 1), f.p, a); err != nil {
+		return nil, err
+	}
+	f.Sub(&xp, xp, FromVec(0, 1))
+	g := f.GCD(a, xp)
+
+	var roots []*gmp.Int
+	f.splitRoots(g, rnd, &roots)
+
+	sort.Slice(roots, func(i, j int) bool { return roots[i].Cmp(roots[j]) < 0 })
+
+	return roots, nil
+}
+
+// splitRoots appends the roots of the monic g, a product of distinct x - r
+func (f *Field) splitRoots(g Polynomial, rnd *rand.Rand, roots *[]*gmp.Int) {
+	switch degree(g) {
+	case 0:
+		return
+	case 1:
+		root := gmp.NewInt(0)
+		root.Neg(g.coeff[0])
+		root.Mod(root, f.p)
+		*roots = append(*roots, root)
+		return
+	}
+
+	// in F_2, g = x (x + 1)
+	if f.p.CmpInt32(2) == 0 {
+		*roots = append(*roots, gmp.NewInt(0), gmp.NewInt(1))
+		return
+	}
+
+	e := gmp.NewInt(0)
+	e.Sub(f.p, gmp.NewInt(1))
+	e.Rsh(e, 1)
+
+	for {
+		// h = gcd((x + delta)^((p-1)/2) - 1, g)
+		shifted := FromVec(0, 1)
+		shifted.coeff[0].Rand(rnd, f.p)
+
+		h := NewEmpty()
+		if err := f.ExpMod(&h, shifted, e, g); err != nil {
+			panic(err.Error())
+		}
+		f.Sub(&h, h, NewOne())
+		h = f.GCD(g, h)
+
+		if d := degree(h); d > 0 && d < degree(g) {
+			rest := NewEmpty()
+			if err := f.Div(&rest, g, h); err != nil {
+				panic(err.Error())
+			}
+			f.splitRoots(h, rnd, roots)
+			f.splitRoots(rest, rnd, roots)
+			return
+		}
+	}
+}
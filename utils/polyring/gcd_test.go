@@ -0,0 +1,197 @@
+package polyring
+
+import (
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/stretchr/testify/assert"
+)
+
+// smallPrimes are the fields of the brute force tests
+var smallPrimes = []int64{2, 3, 5, 7, 13}
+
+// randPoly returns a random polynomial of degree at most deg mod p, possibly 0
+func randPoly(deg int, p *gmp.Int) Polynomial {
+	poly, _ := New(deg)
+	for i := range poly.coeff {
+		poly.coeff[i].Rand(randomness, p)
+	}
+	poly.shrinkToSize()
+	return poly
+}
+
+// bruteForceGCD returns the monic common divisor of largest degree, enumerating all monic polynomials
+func bruteForceGCD(f *Field, a, b Polynomial) Polynomial {
+	if a.IsZero() && b.IsZero() {
+		return NewEmpty()
+	}
+
+	p := f.p.Int64()
+	maxDeg := degree(a)
+	if degree(b) >= 0 && (maxDeg < 0 || degree(b) < maxDeg) {
+		maxDeg = degree(b)
+	}
+
+	for d := maxDeg; d > 0; d-- {
+		count := int64(1)
+		for i := 0; i < d; i++ {
+			count *= p
+		}
+
+		for n := int64(0); n < count; n++ {
+			cand, _ := New(d)
+			cand.coeff[d].SetInt64(1)
+			for i, m := 0, n; i < d; i, m = i+1, m/p {
+				cand.coeff[i].SetInt64(m % p)
+			}
+
+			q, r1, r2 := NewEmpty(), NewEmpty(), NewEmpty()
+			f.DivMod(a, cand, &q, &r1)
+			f.DivMod(b, cand, &q, &r2)
+			if r1.IsZero() && r2.IsZero() {
+				return cand
+			}
+		}
+	}
+
+	return NewOne()
+}
+
+func TestField_GCD_BruteForce(t *testing.T) {
+	for _, prime := range smallPrimes {
+		p := gmp.NewInt(prime)
+		f, err := NewField(p)
+		assert.Nil(t, err, "NewField")
+
+		for i := 0; i < 30; i++ {
+			// a common factor makes non-trivial gcds likely
+			common := randPoly(randomness.Intn(3), p)
+			a, b := NewEmpty(), NewEmpty()
+			f.Mul(&a, common, randPoly(randomness.Intn(3), p))
+			f.Mul(&b, common, randPoly(randomness.Intn(3), p))
+
+			expected := bruteForceGCD(f, a, b)
+			assert.True(t, f.GCD(a, b).IsSame(expected), "GCD(%s, %s) mod %d", a, b, prime)
+
+			// s a + t b = g
+			g, s, tt := f.ExtendedGCD(a, b)
+			assert.True(t, g.IsSame(expected), "ExtendedGCD(%s, %s) mod %d", a, b, prime)
+
+			sa, tb := NewEmpty(), NewEmpty()
+			f.Mul(&sa, s, a)
+			f.Mul(&tb, tt, b)
+			f.Add(&sa, sa, tb)
+			assert.True(t, sa.IsSame(g), "Bezout of (%s, %s) mod %d", a, b, prime)
+		}
+	}
+}
+
+func TestField_HalfGCD(t *testing.T) {
+	f, err := NewField(ScalarField)
+	assert.Nil(t, err, "NewField")
+
+	for _, deg := range []int{40, 65, 128} {
+		common, _ := f.Rand(deg/4, randomness)
+		a, b := NewEmpty(), NewEmpty()
+		x, _ := f.Rand(deg-deg/4, randomness)
+		y, _ := f.Rand(deg-deg/4-1-randomness.Intn(5), randomness)
+		f.Mul(&a, common, x)
+		f.Mul(&b, common, y)
+
+		// the degrees of M (a, b) straddle half the degree of a
+		m := f.HalfGCD(a, b)
+		c, d := f.Apply(m, a, b)
+		half := (degree(a) + 1) / 2
+		assert.True(t, degree(c) >= half && degree(d) < half, "HalfGCD degrees %d, %d around %d", degree(c), degree(d), half)
+
+		// GCD with and without HalfGCD
+		g := f.GCD(a, b)
+		threshold := GCDThreshold
+		GCDThreshold = 1 << 20
+		expected := f.GCD(a, b)
+		GCDThreshold = threshold
+		assert.True(t, g.IsSame(expected), "GCD of degree %d", deg)
+		assert.True(t, degree(g) >= deg/4, "common factor")
+
+		g, s, tt := f.ExtendedGCD(a, b)
+		sa, tb := NewEmpty(), NewEmpty()
+		f.Mul(&sa, s, a)
+		f.Mul(&tb, tt, b)
+		f.Add(&sa, sa, tb)
+		assert.True(t, sa.IsSame(g), "Bezout of degree %d", deg)
+	}
+}
+
+func TestField_ExpMod(t *testing.T) {
+	f, _ := NewField(gmp.NewInt(13))
+	base, mod := FromVec(3, 1, 4), FromVec(1, 5, 9, 2, 6)
+
+	expected := NewOne()
+	q := NewEmpty()
+	for e := 0; e < 20; e++ {
+		res := NewEmpty()
+		assert.Nil(t, f.ExpMod(&res, base, gmp.NewInt(int64(e)), mod), "ExpMod")
+		assert.True(t, res.IsSame(expected), "ExpMod %d", e)
+
+		f.Mul(&expected, expected, base)
+		f.DivMod(expected, mod, &q, &expected)
+	}
+
+	assert.NotNil(t, f.ExpMod(&q, base, gmp.NewInt(2), NewEmpty()), "mod 0")
+}
+
+func TestField_Roots_BruteForce(t *testing.T) {
+	for _, prime := range append(smallPrimes, 11) {
+		p := gmp.NewInt(prime)
+		f, _ := NewField(p)
+
+		for i := 0; i < 30; i++ {
+			poly := randPoly(1+randomness.Intn(8), p)
+			if poly.IsZero() {
+				continue
+			}
+
+			var expected []*gmp.Int
+			y := gmp.NewInt(0)
+			for x := int64(0); x < prime; x++ {
+				f.Eval(y, poly, gmp.NewInt(x))
+				if y.CmpInt32(0) == 0 {
+					expected = append(expected, gmp.NewInt(x))
+				}
+			}
+
+			roots, err := f.Roots(poly, randomness)
+			assert.Nil(t, err, "Roots")
+			assert.Equal(t, len(expected), len(roots), "roots of %s mod %d", poly, prime)
+			for j := range roots {
+				if j < len(expected) {
+					assert.Zero(t, roots[j].Cmp(expected[j]), "roots of %s mod %d", poly, prime)
+				}
+			}
+		}
+	}
+
+	f, _ := NewField(gmp.NewInt(5))
+	_, err := f.Roots(FromVec(5, 10), randomness)
+	assert.NotNil(t, err, "0 mod p")
+}
+
+func TestField_Roots(t *testing.T) {
+	f, _ := NewField(ScalarField)
+
+	// a split polynomial with a double root
+	expected := make([]*gmp.Int, 8)
+	VecInit(expected)
+	VecRand(expected, ScalarField, randomness)
+
+	poly := f.FromRoots(append(expected, expected[0]))
+	roots, err := f.Roots(poly, randomness)
+	assert.Nil(t, err, "Roots")
+	assert.Equal(t, len(expected), len(roots), "number of roots")
+
+	y := gmp.NewInt(0)
+	for _, r := range roots {
+		f.Eval(y, poly, r)
+		assert.Zero(t, y.CmpInt32(0), "root")
+	}
+}
@@ -0,0 +1,196 @@
+package polyring
+
+import (
+	"fmt"
+
+	"github.com/ncw/gmp"
+)
+
+// IsPowerOfTwo returns if n is a positive power of two
+func IsPowerOfTwo(n int) bool {
+	return n > 0 && n&(n-1) == 0
+}
+
+// RootOfUnity returns a primitive n-th root of unity mod p.
+// n must be a power of two that divides p-1
+func RootOfUnity(n int, p *gmp.Int) (*gmp.Int, error) {
+	if !IsPowerOfTwo(n) {
+		return nil, fmt.Errorf("n must be a power of two, got %d", n)
+	}
+
+	order := gmp.NewInt(0)
+	order.Sub(p, gmp.NewInt(1))
+
+	exp, rem := gmp.NewInt(0), gmp.NewInt(0)
+	exp.DivMod(order, gmp.NewInt(int64(n)), rem)
+	if rem.CmpInt32(0) != 0 {
+		return nil, fmt.Errorf("%d does not divide p-1", n)
+	}
+
+	if n == 1 {
+		return gmp.NewInt(1), nil
+	}
+
+	// omega = g^((p-1)/n) is primitive iff omega^(n/2) = -1
+	omega, half := gmp.NewInt(0), gmp.NewInt(0)
+	for g := int64(2); ; g++ {
+		omega.Exp(gmp.NewInt(g), exp, p)
+		half.Exp(omega, gmp.NewInt(int64(n/2)), p)
+		if half.Cmp(order) == 0 {
+			return omega, nil
+		}
+	}
+}
+
+// BitReverse calls swap to permute n items into bit-reversed index order, as needed by an in-place FFT
+func BitReverse(n int, swap func(i, j int)) {
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+
+		if i < j {
+			swap(i, j)
+		}
+	}
+}
+
+// NTT sets a to its number theoretic transform, a[i] = sum_j a[j] omega^{ij} mod p.
+// len(a) must be a power of two and omega a primitive len(a)-th root of unity.
+// Use omega^-1 and scale by len(a)^-1 for the inverse transform
+func NTT(a []*gmp.Int, omega *gmp.Int, p *gmp.Int) {
+	n := len(a)
+	BitReverse(n, func(i, j int) { a[i], a[j] = a[j], a[i] })
+
+	// twiddles[i] = omega^i, omega^(n/m) is a primitive m-th root of unity
+	twiddles := make([]*gmp.Int, n/2)
+	for i := range twiddles {
+		twiddles[i] = gmp.NewInt(1)
+		if i > 0 {
+			twiddles[i].Mul(twiddles[i-1], omega)
+			twiddles[i].Mod(twiddles[i], p)
+		}
+	}
+
+	// the sums are not reduced: each level adds at most p to their absolute value, which costs
+	// less than a reduction with gmp. Only the products are, and the results at the end
+	t := gmp.NewInt(0)
+	for m := 2; m <= n; m <<= 1 {
+		stride := n / m
+
+		for k := 0; k < n; k += m {
+			for j := 0; j < m/2; j++ {
+				t.Mul(twiddles[j*stride], a[k+j+m/2])
+				t.Mod(t, p)
+
+				a[k+j+m/2].Sub(a[k+j], t)
+				a[k+j].Add(a[k+j], t)
+			}
+		}
+	}
+
+	for i := range a {
+		a[i].Mod(a[i], p)
+	}
+}
+
+// NTTThreshold is the degree of the smaller operand from which Field.Mul switches from the schoolbook
+// multiplication to MulNTT. Each gmp call goes through cgo, so the crossover is higher than the operation
+// counts suggest; see BenchmarkField_Mul to tune it
+var NTTThreshold = 768
+
+// SupportsNTT returns if polynomials with n coefficients can be transformed, i.e. if the smallest power of two
+// no smaller than n divides p-1
+func (f *Field) SupportsNTT(n int) bool {
+	return nttSize(n) <= 1<<uint(f.twoAdicity)
+}
+
+// nttSize returns the smallest power of two no smaller than n
+func nttSize(n int) int {
+	size := 1
+	for size < n {
+		size <<= 1
+	}
+	return size
+}
+
+// rootOfUnity returns a primitive n-th root of unity, n must be a power of two dividing p-1
+func (f *Field) rootOfUnity(n int) *gmp.Int {
+	// root^(2^twoAdicity / n)
+	omega := gmp.NewInt(0)
+	omega.Exp(f.root, gmp.NewInt(int64((1<<uint(f.twoAdicity))/n)), f.p)
+	return omega
+}
+
+// MulNTT sets res to op1 * op2 mod p by transforming both operands, multiplying pointwise and transforming back.
+// Complexity is O(n log n) for n = deg1 + deg2 + 1, which must be supported by SupportsNTT
+func (f *Field) MulNTT(res *Polynomial, op1 Polynomial, op2 Polynomial) error {
+	deg1, deg2 := op1.GetDegree(), op2.GetDegree()
+	if !f.SupportsNTT(deg1 + deg2 + 1) {
+		return fmt.Errorf("p-1 is not divisible by a power of two no smaller than %d", deg1+deg2+1)
+	}
+
+	n := nttSize(deg1 + deg2 + 1)
+	omega := f.rootOfUnity(n)
+
+	a, b := make([]*gmp.Int, n), make([]*gmp.Int, n)
+	VecInit(a)
+	VecInit(b)
+	for i := 0; i <= deg1; i++ {
+		a[i].Mod(op1.coeff[i], f.p)
+	}
+	for i := 0; i <= deg2; i++ {
+		b[i].Mod(op2.coeff[i], f.p)
+	}
+
+	NTT(a, omega, f.p)
+	NTT(b, omega, f.p)
+	for i := range a {
+		a[i].Mul(a[i], b[i])
+		a[i].Mod(a[i], f.p)
+	}
+
+	// inverse transform: NTT with omega^-1, scaled by n^-1
+	omegaInv, nInv := gmp.NewInt(0), gmp.NewInt(0)
+	omegaInv.ModInverse(omega, f.p)
+	nInv.ModInverse(gmp.NewInt(int64(n)), f.p)
+	NTT(a, omegaInv, f.p)
+
+	prod, err := New(deg1 + deg2)
+	if err != nil {
+		return err
+	}
+	for i := range prod.coeff {
+		prod.coeff[i].Mul(a[i], nInv)
+		prod.coeff[i].Mod(prod.coeff[i], f.p)
+	}
+
+	f.Reduce(&prod)
+	res.ResetTo(prod)
+
+	return nil
+}
+
+// FromRoots returns (x - roots[0]) ... (x - roots[n-1]) mod p, multiplying the halves recursively
+// so that the large products can use MulNTT
+func (f *Field) FromRoots(roots []*gmp.Int) Polynomial {
+	switch len(roots) {
+	case 0:
+		return NewOne()
+	case 1:
+		root := FromVec(0, 1)
+		root.GetPtrToConstant().Neg(roots[0])
+		f.Reduce(&root)
+		return root
+	}
+
+	left := f.FromRoots(roots[:len(roots)/2])
+	right := f.FromRoots(roots[len(roots)/2:])
+
+	prod := NewEmpty()
+	f.Mul(&prod, left, right)
+
+	return prod
+}
@@ -0,0 +1,194 @@
+package polyring
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/stretchr/testify/assert"
+)
+
+// ScalarField is the order of the group of ecparam.PBC256, whose 2-adicity is 41
+var ScalarField, _ = new(gmp.Int).SetString("57896044618658097711785492504343953926634992332820282019728792006155588075521", 10)
+
+func TestRootOfUnity(t *testing.T) {
+	for _, n := range []int{1, 2, 8, 1 << 20} {
+		omega, err := RootOfUnity(n, ScalarField)
+		assert.Nil(t, err, "RootOfUnity")
+
+		res := gmp.NewInt(0)
+		res.Exp(omega, gmp.NewInt(int64(n)), ScalarField)
+		assert.Zero(t, res.CmpInt32(1), "omega^n")
+
+		if n > 1 {
+			res.Exp(omega, gmp.NewInt(int64(n/2)), ScalarField)
+			assert.NotZero(t, res.CmpInt32(1), "primitive")
+		}
+	}
+
+	_, err := RootOfUnity(6, ScalarField)
+	assert.NotNil(t, err, "not a power of two")
+
+	_, err = RootOfUnity(1<<42, ScalarField)
+	assert.NotNil(t, err, "does not divide p-1")
+}
+
+func TestNTT(t *testing.T) {
+	const n = 16
+	p := ScalarField
+
+	omega, err := RootOfUnity(n, p)
+	assert.Nil(t, err, "RootOfUnity")
+
+	a := make([]*gmp.Int, n)
+	VecInit(a)
+	VecRand(a, p, randomness)
+
+	// expected[i] = a(omega^i)
+	poly, _ := New(n - 1)
+	for i := range a {
+		poly.SetCoefficientBig(i, a[i])
+	}
+	expected := make([]*gmp.Int, n)
+	VecInit(expected)
+	x := gmp.NewInt(1)
+	for i := range expected {
+		poly.EvalMod(x, p, expected[i])
+		x.Mul(x, omega)
+		x.Mod(x, p)
+	}
+
+	NTT(a, omega, p)
+	for i := range a {
+		assert.Zero(t, a[i].Cmp(expected[i]), "NTT %d", i)
+	}
+
+	// inverse transform
+	omegaInv, nInv := gmp.NewInt(0), gmp.NewInt(0)
+	omegaInv.ModInverse(omega, p)
+	nInv.ModInverse(gmp.NewInt(n), p)
+
+	NTT(a, omegaInv, p)
+	for i := range a {
+		a[i].Mul(a[i], nInv)
+		a[i].Mod(a[i], p)
+		assert.Zero(t, a[i].Cmp(poly.coeff[i]), "inverse NTT %d", i)
+	}
+}
+
+func TestField_MulNTT(t *testing.T) {
+	f, err := NewField(ScalarField)
+	assert.Nil(t, err, "NewField")
+	assert.True(t, f.SupportsNTT(1<<41), "2-adicity")
+	assert.False(t, f.SupportsNTT(1<<41+1), "2-adicity")
+
+	// make Mul switch to MulNTT for the larger operands
+	defer func(threshold int) { NTTThreshold = threshold }(NTTThreshold)
+	NTTThreshold = 8
+
+	for _, deg := range [][2]int{{0, 0}, {1, 5}, {7, 8}, {31, 100}, {200, 200}} {
+		a, _ := f.Rand(deg[0], randomness)
+		b, _ := f.Rand(deg[1], randomness)
+
+		expected := NewEmpty()
+		expected.Mul(a, b)
+		expected.Mod(ScalarField)
+
+		res := NewEmpty()
+		assert.Nil(t, f.MulNTT(&res, a, b), "MulNTT")
+		assert.True(t, res.IsSame(expected), "MulNTT %v", deg)
+
+		// Mul switches to MulNTT above the threshold
+		f.Mul(&res, a, b)
+		assert.True(t, res.IsSame(expected), "Mul %v", deg)
+	}
+
+	// 11-1 = 2 * 5, so F_11 only has square roots of unity
+	small, err := NewField(gmp.NewInt(11))
+	assert.Nil(t, err, "NewField")
+	assert.False(t, small.SupportsNTT(3), "2-adicity of 11")
+	res := NewEmpty()
+	assert.NotNil(t, small.MulNTT(&res, FromVec(1, 1), FromVec(1, 1)), "MulNTT mod 11")
+
+	// Mul falls back to the schoolbook multiplication
+	a, _ := small.Rand(NTTThreshold, randomness)
+	expected := NewEmpty()
+	expected.Mul(a, a)
+	expected.Mod(gmp.NewInt(11))
+	small.Mul(&res, a, a)
+	assert.True(t, res.IsSame(expected), "Mul mod 11")
+}
+
+func TestField_FromRoots(t *testing.T) {
+	f, err := NewField(ScalarField)
+	assert.Nil(t, err, "NewField")
+
+	roots := make([]*gmp.Int, 150)
+	VecInit(roots)
+	VecRand(roots, ScalarField, randomness)
+
+	poly := f.FromRoots(roots)
+	assert.Equal(t, len(roots), poly.GetDegree(), "degree")
+	assert.Zero(t, poly.coeff[len(roots)].CmpInt32(1), "monic")
+
+	y := gmp.NewInt(0)
+	for i := range roots {
+		f.Eval(y, poly, roots[i])
+		assert.Zero(t, y.CmpInt32(0), "root %d", i)
+	}
+
+	assert.True(t, f.FromRoots(nil).IsSame(NewOne()), "no roots")
+}
+
+// BenchmarkField_Mul compares the schoolbook multiplication followed by Mod with MulNTT
+func BenchmarkField_Mul(b *testing.B) {
+	f, _ := NewField(ScalarField)
+
+	for _, deg := range []int{16, 64, 256, 512, 1024} {
+		op1, _ := f.Rand(deg, randomness)
+		op2, _ := f.Rand(deg, randomness)
+		res := NewEmpty()
+
+		b.Run(fmt.Sprintf("Schoolbook/%d", deg), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				res.Mul(op1, op2)
+				res.Mod(ScalarField)
+			}
+		})
+
+		b.Run(fmt.Sprintf("NTT/%d", deg), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				f.MulNTT(&res, op1, op2)
+			}
+		})
+	}
+}
+
+// BenchmarkField_FromRoots compares multiplying the roots one at a time, as LagrangeInterpolate used to, with FromRoots
+func BenchmarkField_FromRoots(b *testing.B) {
+	f, _ := NewField(ScalarField)
+
+	for _, n := range []int{64, 256} {
+		roots := make([]*gmp.Int, n)
+		VecInit(roots)
+		VecRand(roots, ScalarField, randomness)
+
+		b.Run(fmt.Sprintf("MulSelf/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				product := NewOne()
+				root := FromVec(0, 1)
+				for _, r := range roots {
+					root.GetPtrToConstant().Neg(r)
+					product.MulSelf(root)
+				}
+				product.Mod(ScalarField)
+			}
+		})
+
+		b.Run(fmt.Sprintf("FromRoots/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				f.FromRoots(roots)
+			}
+		})
+	}
+}
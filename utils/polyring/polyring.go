@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/parallel"
 )
 
 // Polynomial struct
@@ -234,38 +235,25 @@ func FromVec(coeff ...int64) Polynomial {
 	return poly
 }
 
-// FromString returns a polynomial from an input string
-func FromString(s string) Polynomial {
-	// Convert input string to slice of gmp.Int
+// FromString returns the polynomial printed by String, the decimal coefficients from degree 0 separated by ';'.
+// Leading zero coefficients are dropped
+func FromString(s string) (Polynomial, error) {
 	coeffStr := strings.Split(s, ";")
-	if len(coeffStr) == 0 {
-		return NewConstant(0)
-	}
-	coeff := make([]*gmp.Int, 0)
-	for i := 0; i < len(coeffStr); i++ {
-		tmp := gmp.NewInt(0)
-		tmp.SetString(coeffStr[i], 10)
-		coeff = append(coeff, tmp)
-	}
-	// Determine poly degree
-	deg := len(coeff) - 1
-	for i := len(coeff) - 1; i >= 0; i-- {
-		if coeff[i].CmpInt32(0) != 0 {
-			continue
-		}
-		deg = i
-	}
-	// Create and return new poly
-	poly, err := New(deg)
+
+	poly, err := New(len(coeffStr) - 1)
 	if err != nil {
-		panic(err.Error())
+		return Polynomial{}, err
 	}
 
-	for i := range poly.coeff {
-		poly.coeff[i].Set(coeff[i])
+	for i := range coeffStr {
+		if _, ok := poly.coeff[i].SetString(strings.TrimSpace(coeffStr[i]), 10); !ok {
+			return Polynomial{}, fmt.Errorf("coefficient %d: invalid integer %q", i, coeffStr[i])
+		}
 	}
 
-	return poly
+	poly.shrinkToSize()
+
+	return poly, nil
 }
 
 // Rand sets the polynomial coefficients to a pseudo-random number in [0, n)
@@ -404,6 +392,11 @@ func (poly *Polynomial) Sub(op1 Polynomial, op2 Polynomial) error {
 		poly.coeff[i].Sub(op1.coeff[i], op2.coeff[i])
 	}
 
+	// the higher coefficients were copied from op2 and must be negated
+	for i := deg1 + 1; i <= deg2; i++ {
+		poly.coeff[i].Neg(poly.coeff[i])
+	}
+
 	poly.shrinkToSize()
 
 	// FIXME: no need to return error
@@ -459,30 +452,47 @@ func (poly *Polynomial) AddMul(poly2 Polynomial, k *gmp.Int) {
 }
 
 // DivMod sets computes q, r such that a = b*q + r.
-// This is an implementation of Euclidean division. The complexity is O(n^3)!!
+// If p is prime and the quotient has degree at least DivThreshold, it uses Field.DivModNewton.
+// Otherwise, it uses the Euclidean division of divModNaive
 func DivMod(a Polynomial, b Polynomial, p *gmp.Int, q, r *Polynomial) (err error) {
 	if b.IsZero() {
 		return errors.New("divide by zero")
 	}
 
+	if useNewton(a, b) {
+		if field, err := GetField(p); err == nil {
+			return field.DivModNewton(a, b, q, r)
+		}
+	}
+
+	return divModNaive(a, b, p, q, r)
+}
+
+// divModNaive is an implementation of Euclidean division. The complexity is O(n^3)!!
+func divModNaive(a Polynomial, b Polynomial, p *gmp.Int, q, r *Polynomial) (err error) {
+	if b.IsZero() {
+		return errors.New("divide by zero")
+	}
+
 	q.resetToDegree(0)
 	r.ResetTo(a)
 
 	d := b.GetDegree()
-	c := b.GetLeadingCoefficient()
 
-	// cInv = 1/c
+	// cInv = 1/c, the coefficients are used through their pointers: a copy of a gmp.Int shares
+	// its limbs with the original, and they are freed when the original is finalized
 	cInv := gmp.NewInt(0)
-	cInv.ModInverse(&c, p)
+	cInv.ModInverse(b.coeff[d], p)
 
-	for r.GetDegree() >= d {
-		lc := r.GetLeadingCoefficient()
+	lc := gmp.NewInt(0)
+	for r.GetDegree() >= d && !r.IsZero() {
+		lc.Mul(r.coeff[r.GetDegree()], cInv)
 		s, err := New(r.GetDegree() - d)
 		if err != nil {
 			return err
 		}
 
-		s.SetCoefficientBig(r.GetDegree()-d, lc.Mul(&lc, cInv))
+		s.SetCoefficientBig(r.GetDegree()-d, lc)
 
 		q.AddSelf(s)
 
@@ -502,6 +512,8 @@ func DivMod(a Polynomial, b Polynomial, p *gmp.Int, q, r *Polynomial) (err error
 
 // Div2 sets poly to op1 / op2. **op2 must be of format x+a **
 // Complexity is O(deg1)
+// The coefficients are divided by the leading coefficient of op2 with integer division, which is only exact
+// if op2 is monic. Use Field.Div2 to divide mod p
 func (poly *Polynomial) Div2(op1 Polynomial, op2 Polynomial) error {
 	deg1 := op1.GetDegree()
 	deg2 := op2.GetDegree()
@@ -561,12 +573,28 @@ func (poly Polynomial) EvalMod(x *gmp.Int, p *gmp.Int, result *gmp.Int) {
 }
 
 // EvalModArray returns poly[x[1]], ..., poly[x[n]]
+// From MultipointThreshold points on, it evaluates with a SubproductTree if mod is prime
 func (poly Polynomial) EvalModArray(x []*gmp.Int, mod *gmp.Int, results []*gmp.Int) {
+	if mod != nil && len(x) >= MultipointThreshold {
+		if field, err := GetField(mod); err == nil {
+			field.EvalMultipoint(poly, x, results)
+			return
+		}
+	}
+
 	for i := 0; i < len(x); i++ {
 		poly.EvalMod(x[i], mod, results[i])
 	}
 }
 
+// EvalModArrayParallel is EvalModArray with the points split into chunks evaluated on w goroutines,
+// w <= 0 meaning parallel.Workers. The polynomial and the points are only read
+func (poly Polynomial) EvalModArrayParallel(x []*gmp.Int, mod *gmp.Int, results []*gmp.Int, w int) {
+	parallel.Chunks(len(x), w, func(_, lo, hi int) {
+		poly.EvalModArray(x[lo:hi], mod, results[lo:hi])
+	})
+}
+
 // IsSame returns op == poly
 func (poly Polynomial) IsSame(op Polynomial) bool {
 	if op.GetDegree() != poly.GetDegree() {
@@ -1,6 +1,7 @@
 package polyring
 
 import (
+	"fmt"
 	"math/rand"
 	"testing"
 
@@ -121,6 +122,9 @@ func TestPolynomial_Sub(t *testing.T) {
 	}{
 		{[]int64{1, 1}, []int64{0, 1}, []int64{1}},
 		{[]int64{1, 1, 1}, []int64{1, 1, 1}, []int64{0}},
+		// op2 longer than op1: its higher coefficients are negated
+		{[]int64{1, 2}, []int64{0, 1, 3, 4}, []int64{1, 1, -3, -4}},
+		{[]int64{5}, []int64{1, 0, 2}, []int64{4, 0, -2}},
 	}
 
 	for _, test := range tests {
@@ -131,7 +135,7 @@ func TestPolynomial_Sub(t *testing.T) {
 		result, _ := New(op1.GetDegree())
 		result.Sub(op1, op2)
 
-		assert.True(t, expected.IsSame(result))
+		assert.True(t, expected.IsSame(result), "%v - %v", test.op1, test.op2)
 	}
 
 }
@@ -323,6 +327,27 @@ func TestPolynomial_EvalModArray(t *testing.T) {
 	}
 }
 
+func TestPolynomial_EvalModArrayParallel(t *testing.T) {
+	poly, _ := NewRand(40, randomness, ScalarField)
+
+	x := make([]*gmp.Int, 100)
+	VecInit(x)
+	VecRand(x, ScalarField, randomness)
+
+	expected := make([]*gmp.Int, len(x))
+	VecInit(expected)
+	poly.EvalModArray(x, ScalarField, expected)
+
+	for _, w := range []int{0, 1, 3, 200} {
+		results := make([]*gmp.Int, len(x))
+		VecInit(results)
+		poly.EvalModArrayParallel(x, ScalarField, results, w)
+		for i := range results {
+			assert.Zero(t, results[i].Cmp(expected[i]), "workers %d, point %d", w, i)
+		}
+	}
+}
+
 func TestPolynomial_GetLeadingCoefficient(t *testing.T) {
 	var tests = []struct {
 		coeffs   []int64
@@ -338,6 +363,11 @@ func TestPolynomial_GetLeadingCoefficient(t *testing.T) {
 		p := FromVec(test.coeffs...)
 		lc := p.GetLeadingCoefficient()
 		assert.Equal(t, 0, gmp.NewInt(test.expected).Cmp(&lc))
+
+		// the result is a copy
+		lc.Add(&lc, gmp.NewInt(1))
+		lc = p.GetLeadingCoefficient()
+		assert.Equal(t, 0, gmp.NewInt(test.expected).Cmp(&lc), "polynomial unchanged")
 	}
 }
 
@@ -374,6 +404,7 @@ func TestDiv(t *testing.T) {
 		{[]int64{7, 0, 0, 0, 2, 1}, []int64{-5, 0, 0, 1}, []int64{0, 2, 1}, []int64{7, 10, 5}},
 		{[]int64{7, 10, 5, 2}, []int64{4, 0, 1}, []int64{5, 2}, []int64{4, 2}},
 		{[]int64{1, 2, 1}, []int64{1, 2}, []int64{5, 9}, []int64{13}},
+		{[]int64{1, 2, 1}, []int64{2}, []int64{9, 1, 9}, []int64{}},
 	}
 
 	for _, test := range tests {
@@ -390,3 +421,119 @@ func TestDiv(t *testing.T) {
 		assert.True(t, rr.IsSame(r))
 	}
 }
+
+func TestDivMod_Random(t *testing.T) {
+	// the order of the group of ecparam.PBC256, and a small prime
+	large := gmp.NewInt(0)
+	large.SetString("57896044618658097711785492504343953926634992332820282019728792006155588075521", 10)
+
+	for _, mod := range []*gmp.Int{large, gmp.NewInt(17)} {
+		f, err := NewField(mod)
+		assert.Nil(t, err, "NewField")
+
+		for i := 0; i < 50; i++ {
+			degA := randomness.Intn(100)
+			degB := randomness.Intn(degA + 1)
+			if i%10 == 0 {
+				// b of higher degree than a
+				degB = degA + 1 + randomness.Intn(5)
+			}
+
+			a, _ := NewRand(degA, randomness, mod)
+			b, _ := NewRand(degB, randomness, mod)
+
+			expectedQ, expectedR := NewEmpty(), NewEmpty()
+			assert.Nil(t, divModNaive(a, b, mod, &expectedQ, &expectedR), "divModNaive")
+
+			q, r := NewEmpty(), NewEmpty()
+			assert.Nil(t, f.DivModNewton(a, b, &q, &r), "DivModNewton")
+			assert.True(t, q.IsSame(expectedQ), "quotient of degree %d by %d", degA, degB)
+			assert.True(t, r.IsSame(expectedR), "remainder of degree %d by %d", degA, degB)
+
+			// DivMod picks either
+			assert.Nil(t, DivMod(a, b, mod, &q, &r), "DivMod")
+			assert.True(t, q.IsSame(expectedQ), "quotient of degree %d by %d", degA, degB)
+			assert.True(t, r.IsSame(expectedR), "remainder of degree %d by %d", degA, degB)
+		}
+	}
+
+	// a non-prime modulus falls back to the long division
+	q, r := NewEmpty(), NewEmpty()
+	a := FromVec(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20)
+	assert.Nil(t, DivMod(a, FromVec(1, 1), gmp.NewInt(15), &q, &r), "DivMod mod 15")
+	assert.Equal(t, 18, q.GetDegree(), "quotient mod 15")
+
+	assert.NotNil(t, DivMod(a, NewEmpty(), large, &q, &r), "divide by zero")
+	f, _ := NewField(large)
+	assert.NotNil(t, f.DivModNewton(a, FromVec(0, 0), &q, &r), "divide by zero")
+}
+
+func TestField_InverseSeries(t *testing.T) {
+	mod := gmp.NewInt(17)
+	f, err := NewField(mod)
+	assert.Nil(t, err, "NewField")
+
+	for _, k := range []int{1, 2, 5, 16, 33} {
+		poly, _ := NewRand(randomness.Intn(40), randomness, mod)
+		poly.GetPtrToConstant().SetInt64(3)
+
+		inv := NewEmpty()
+		assert.Nil(t, f.InverseSeries(&inv, poly, k), "InverseSeries")
+		assert.True(t, inv.GetDegree() < k, "degree")
+
+		prod := NewEmpty()
+		f.Mul(&prod, poly, inv)
+		assert.True(t, truncate(prod, k).IsSame(NewOne()), "poly * inv = 1 mod x^%d", k)
+	}
+
+	inv := NewEmpty()
+	assert.NotNil(t, f.InverseSeries(&inv, FromVec(17, 1), 4), "constant term 0 mod p")
+	assert.NotNil(t, f.InverseSeries(&inv, FromVec(1, 1), 0), "k = 0")
+}
+
+// BenchmarkDivMod compares the long division with DivModNewton
+func BenchmarkDivMod(b *testing.B) {
+	mod := gmp.NewInt(0)
+	mod.SetString("57896044618658097711785492504343953926634992332820282019728792006155588075521", 10)
+	f, _ := NewField(mod)
+
+	for _, deg := range []int{16, 64, 256} {
+		a, _ := NewRand(2*deg, randomness, mod)
+		d, _ := NewRand(deg, randomness, mod)
+		q, r := NewEmpty(), NewEmpty()
+
+		b.Run(fmt.Sprintf("Naive/%d", deg), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				divModNaive(a, d, mod, &q, &r)
+			}
+		})
+
+		b.Run(fmt.Sprintf("Newton/%d", deg), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				f.DivModNewton(a, d, &q, &r)
+			}
+		})
+	}
+}
+
+// BenchmarkEvalModArray compares EvalModArray with EvalModArrayParallel on parallel.Workers goroutines
+func BenchmarkEvalModArray(b *testing.B) {
+	poly, _ := NewRand(128, randomness, ScalarField)
+	x := make([]*gmp.Int, 200)
+	VecInit(x)
+	VecRand(x, ScalarField, randomness)
+	results := make([]*gmp.Int, len(x))
+	VecInit(results)
+
+	b.Run("Serial", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			poly.EvalModArray(x, ScalarField, results)
+		}
+	})
+
+	b.Run("Parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			poly.EvalModArrayParallel(x, ScalarField, results, 0)
+		}
+	})
+}
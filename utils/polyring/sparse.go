@@ -0,0 +1,317 @@
+package polyring
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ncw/gmp"
+)
+
+// SparsePolynomial is a polynomial that only stores its non-zero terms, for polynomials such as x^n - 1 or
+// update polynomials, which a Polynomial would store with one gmp.Int per coefficient. As with Polynomial,
+// the arithmetic is over the integers and the methods taking p reduce mod p
+type SparsePolynomial struct {
+	terms []term // non-zero terms by increasing degree
+}
+
+// term is coeff x^degree
+type term struct {
+	degree int
+	coeff  *gmp.Int
+}
+
+// NewSparse returns the polynomial with coefficient terms[d] in degree d
+func NewSparse(terms map[int]*gmp.Int) (SparsePolynomial, error) {
+	var sp SparsePolynomial
+	for d, c := range terms {
+		if d < 0 {
+			return SparsePolynomial{}, fmt.Errorf("degree must be non-negative, got %d", d)
+		}
+		if c.CmpInt32(0) != 0 {
+			sp.terms = append(sp.terms, term{d, new(gmp.Int).Set(c)})
+		}
+	}
+
+	sort.Slice(sp.terms, func(i, j int) bool { return sp.terms[i].degree < sp.terms[j].degree })
+
+	return sp, nil
+}
+
+// NewMonomial returns the polynomial c x^degree
+func NewMonomial(c int64, degree int) SparsePolynomial {
+	if degree < 0 {
+		panic(fmt.Sprintf("degree must be non-negative, got %d", degree))
+	}
+	if c == 0 {
+		return SparsePolynomial{}
+	}
+	return SparsePolynomial{[]term{{degree, gmp.NewInt(c)}}}
+}
+
+// ToSparse returns poly as a SparsePolynomial
+func (poly Polynomial) ToSparse() SparsePolynomial {
+	var sp SparsePolynomial
+	for i := 0; i <= poly.GetDegree(); i++ {
+		if poly.coeff[i].CmpInt32(0) != 0 {
+			sp.terms = append(sp.terms, term{i, new(gmp.Int).Set(poly.coeff[i])})
+		}
+	}
+	return sp
+}
+
+// ToDense returns sp as a Polynomial
+func (sp SparsePolynomial) ToDense() Polynomial {
+	poly, err := New(sp.GetDegree())
+	if err != nil {
+		panic(err.Error())
+	}
+	for _, t := range sp.terms {
+		poly.coeff[t.degree].Set(t.coeff)
+	}
+	return poly
+}
+
+// GetDegree returns the degree, 0 for the zero polynomial as for Polynomial
+func (sp SparsePolynomial) GetDegree() int {
+	if len(sp.terms) == 0 {
+		return 0
+	}
+	return sp.terms[len(sp.terms)-1].degree
+}
+
+// NumTerms returns the number of non-zero terms
+func (sp SparsePolynomial) NumTerms() int {
+	return len(sp.terms)
+}
+
+// IsZero returns if sp == 0
+func (sp SparsePolynomial) IsZero() bool {
+	return len(sp.terms) == 0
+}
+
+// GetCoefficient returns a copy of the coefficient of degree d
+func (sp SparsePolynomial) GetCoefficient(d int) *gmp.Int {
+	i := sort.Search(len(sp.terms), func(i int) bool { return sp.terms[i].degree >= d })
+	if i < len(sp.terms) && sp.terms[i].degree == d {
+		return new(gmp.Int).Set(sp.terms[i].coeff)
+	}
+	return gmp.NewInt(0)
+}
+
+// DeepCopy returns a copy of sp
+func (sp SparsePolynomial) DeepCopy() SparsePolynomial {
+	res := SparsePolynomial{make([]term, len(sp.terms))}
+	for i, t := range sp.terms {
+		res.terms[i] = term{t.degree, new(gmp.Int).Set(t.coeff)}
+	}
+	return res
+}
+
+// IsSame returns sp == op
+func (sp SparsePolynomial) IsSame(op SparsePolynomial) bool {
+	if len(sp.terms) != len(op.terms) {
+		return false
+	}
+	for i := range sp.terms {
+		if sp.terms[i].degree != op.terms[i].degree || sp.terms[i].coeff.Cmp(op.terms[i].coeff) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the terms as c x^d separated by " + ", by decreasing degree
+func (sp SparsePolynomial) String() string {
+	if sp.IsZero() {
+		return "0"
+	}
+
+	s := make([]string, len(sp.terms))
+	for i, t := range sp.terms {
+		s[len(sp.terms)-1-i] = fmt.Sprintf("%s x^%d", t.coeff.String(), t.degree)
+	}
+	return strings.Join(s, " + ")
+}
+
+// combine returns op1 + op2, or op1 - op2 if negate, merging the sorted terms
+func combine(op1 SparsePolynomial, op2 SparsePolynomial, negate bool) SparsePolynomial {
+	var res SparsePolynomial
+	i, j := 0, 0
+	for i < len(op1.terms) || j < len(op2.terms) {
+		switch {
+		case j == len(op2.terms) || (i < len(op1.terms) && op1.terms[i].degree < op2.terms[j].degree):
+			res.terms = append(res.terms, term{op1.terms[i].degree, new(gmp.Int).Set(op1.terms[i].coeff)})
+			i++
+		case i == len(op1.terms) || op2.terms[j].degree < op1.terms[i].degree:
+			c := new(gmp.Int).Set(op2.terms[j].coeff)
+			if negate {
+				c.Neg(c)
+			}
+			res.terms = append(res.terms, term{op2.terms[j].degree, c})
+			j++
+		default:
+			c := new(gmp.Int)
+			if negate {
+				c.Sub(op1.terms[i].coeff, op2.terms[j].coeff)
+			} else {
+				c.Add(op1.terms[i].coeff, op2.terms[j].coeff)
+			}
+			if c.CmpInt32(0) != 0 {
+				res.terms = append(res.terms, term{op1.terms[i].degree, c})
+			}
+			i++
+			j++
+		}
+	}
+	return res
+}
+
+// Add sets sp to op1 + op2
+func (sp *SparsePolynomial) Add(op1 SparsePolynomial, op2 SparsePolynomial) {
+	*sp = combine(op1, op2, false)
+}
+
+// Sub sets sp to op1 - op2
+func (sp *SparsePolynomial) Sub(op1 SparsePolynomial, op2 SparsePolynomial) {
+	*sp = combine(op1, op2, true)
+}
+
+// Mul sets sp to op1 * op2, in O(t1 t2 log(t1 t2)) for t1 and t2 terms
+func (sp *SparsePolynomial) Mul(op1 SparsePolynomial, op2 SparsePolynomial) {
+	products := make(map[int]*gmp.Int)
+	for _, t1 := range op1.terms {
+		for _, t2 := range op2.terms {
+			d := t1.degree + t2.degree
+			if products[d] == nil {
+				products[d] = gmp.NewInt(0)
+			}
+			products[d].AddMul(t1.coeff, t2.coeff)
+		}
+	}
+
+	res, _ := NewSparse(products)
+	*sp = res
+}
+
+// Mod sets sp to sp mod p, dropping the terms that become zero
+func (sp *SparsePolynomial) Mod(p *gmp.Int) {
+	terms := sp.terms[:0]
+	for _, t := range sp.terms {
+		t.coeff.Mod(t.coeff, p)
+		if t.coeff.CmpInt32(0) != 0 {
+			terms = append(terms, t)
+		}
+	}
+	sp.terms = terms
+}
+
+// EvalMod sets result to sp(x) mod p, in O(t log deg) with the powers of x computed by square and multiply
+// from one term to the next
+func (sp SparsePolynomial) EvalMod(x *gmp.Int, p *gmp.Int, result *gmp.Int) {
+	acc, power, tmp := gmp.NewInt(0), gmp.NewInt(1), gmp.NewInt(0)
+	prev := 0
+	for _, t := range sp.terms {
+		// power = x^t.degree
+		tmp.Exp(x, gmp.NewInt(int64(t.degree-prev)), p)
+		power.Mul(power, tmp)
+		power.Mod(power, p)
+		prev = t.degree
+
+		tmp.Mul(t.coeff, power)
+		acc.Add(acc, tmp)
+	}
+
+	result.Mod(acc, p)
+}
+
+// AddSparse sets poly to op1 + op2
+func (poly *Polynomial) AddSparse(op1 Polynomial, op2 SparsePolynomial) {
+	res := op1.DeepCopy()
+	res.GrowCapTo(op2.GetDegree() + 1)
+	for _, t := range op2.terms {
+		res.coeff[t.degree].Add(res.coeff[t.degree], t.coeff)
+	}
+	res.shrinkToSize()
+	*poly = res
+}
+
+// MulSparse sets poly to op1 * op2, in O(n t) for n coefficients and t terms
+func (poly *Polynomial) MulSparse(op1 Polynomial, op2 SparsePolynomial) {
+	deg1 := op1.GetDegree()
+	res, err := New(deg1 + op2.GetDegree())
+	if err != nil {
+		panic(err.Error())
+	}
+
+	for _, t := range op2.terms {
+		for i := 0; i <= deg1; i++ {
+			res.coeff[i+t.degree].AddMul(op1.coeff[i], t.coeff)
+		}
+	}
+
+	res.shrinkToSize()
+	*poly = res
+}
+
+// DivModSparse computes q, r such that a = b*q + r mod p, with deg r < deg b, in O((deg a - deg b) t)
+// for t terms in b: each step of the long division only updates the coefficients under the terms of b.
+// The leading coefficient of b must be invertible mod p
+func DivModSparse(a Polynomial, b SparsePolynomial, p *gmp.Int, q, r *Polynomial) error {
+	bRed := b.DeepCopy()
+	bRed.Mod(p)
+	if bRed.IsZero() {
+		return errors.New("divide by zero")
+	}
+
+	degB := bRed.GetDegree()
+	lc := bRed.terms[len(bRed.terms)-1].coeff
+	lcInv, check := gmp.NewInt(0), gmp.NewInt(0)
+	lcInv.ModInverse(lc, p)
+	if check.Mul(lc, lcInv).Mod(check, p).CmpInt32(1) != 0 {
+		return errors.New("leading coefficient is not invertible")
+	}
+
+	rem := a.DeepCopy()
+	rem.Mod(p)
+	degA := rem.GetDegree()
+
+	if degA < degB {
+		q.ResetTo(NewEmpty())
+		r.ResetTo(rem)
+		return nil
+	}
+
+	quot, err := New(degA - degB)
+	if err != nil {
+		return err
+	}
+
+	// the leading term of b cancels rem[i], so only the lower terms are subtracted
+	lower := bRed.terms[:len(bRed.terms)-1]
+	c, tmp := gmp.NewInt(0), gmp.NewInt(0)
+	for i := degA; i >= degB; i-- {
+		c.Mul(rem.coeff[i], lcInv)
+		c.Mod(c, p)
+		rem.coeff[i].SetInt64(0)
+		if c.CmpInt32(0) == 0 {
+			continue
+		}
+
+		quot.coeff[i-degB].Set(c)
+		for _, t := range lower {
+			k := i - degB + t.degree
+			tmp.Mul(c, t.coeff)
+			rem.coeff[k].Sub(rem.coeff[k], tmp)
+			rem.coeff[k].Mod(rem.coeff[k], p)
+		}
+	}
+
+	rem.shrinkToSize()
+	quot.shrinkToSize()
+	q.ResetTo(quot)
+	r.ResetTo(rem)
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package polyring
+
+import (
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/stretchr/testify/assert"
+)
+
+// xnMinusOne returns x^n - 1
+func xnMinusOne(n int) SparsePolynomial {
+	sp := NewMonomial(1, n)
+	sp.Sub(sp, NewMonomial(1, 0))
+	return sp
+}
+
+func TestSparsePolynomial_Conversion(t *testing.T) {
+	dense := FromVec(3, 0, 0, -2, 0, 0, 0, 5)
+	sp := dense.ToSparse()
+	assert.Equal(t, 3, sp.NumTerms())
+	assert.Equal(t, 7, sp.GetDegree())
+	assert.Equal(t, "-2", sp.GetCoefficient(3).String())
+	assert.Equal(t, "0", sp.GetCoefficient(4).String())
+	assert.Equal(t, "5 x^7 + -2 x^3 + 3 x^0", sp.String())
+	assert.True(t, dense.IsSame(sp.ToDense()), "round trip")
+
+	fromMap, err := NewSparse(map[int]*gmp.Int{7: gmp.NewInt(5), 0: gmp.NewInt(3), 3: gmp.NewInt(-2), 5: gmp.NewInt(0)})
+	assert.Nil(t, err, "NewSparse")
+	assert.True(t, sp.IsSame(fromMap), "NewSparse drops zeros and sorts")
+
+	_, err = NewSparse(map[int]*gmp.Int{-1: gmp.NewInt(1)})
+	assert.NotNil(t, err, "negative degree")
+
+	assert.True(t, NewEmpty().ToSparse().IsZero())
+	assert.True(t, SparsePolynomial{}.ToDense().IsZero())
+}
+
+func TestSparsePolynomial_Arithmetic(t *testing.T) {
+	a := FromVec(1, 0, 4, 0, 0, 7).ToSparse()
+	b := FromVec(2, 0, -4, 1).ToSparse()
+
+	var res SparsePolynomial
+	expected := NewEmpty()
+
+	res.Add(a, b)
+	expected.Add(a.ToDense(), b.ToDense())
+	assert.True(t, expected.IsSame(res.ToDense()), "Add")
+	assert.Equal(t, 3, res.NumTerms(), "cancelled term dropped")
+
+	res.Sub(a, b)
+	expected.Sub(a.ToDense(), b.ToDense())
+	assert.True(t, expected.IsSame(res.ToDense()), "Sub")
+
+	res.Sub(a, a)
+	assert.True(t, res.IsZero(), "a - a")
+
+	res.Mul(a, b)
+	expected.Mul(a.ToDense(), b.ToDense())
+	assert.True(t, expected.IsSame(res.ToDense()), "Mul")
+
+	// x^4 - 1 = (x^2 - 1)(x^2 + 1)
+	res.Mul(xnMinusOne(2), FromVec(1, 0, 1).ToSparse())
+	assert.True(t, res.IsSame(xnMinusOne(4)), "x^4 - 1")
+
+	// interoperating with Polynomial
+	dense := FromVec(5, 1, 0, 3)
+	denseRes := NewEmpty()
+	denseRes.AddSparse(dense, b)
+	expected.Add(dense, b.ToDense())
+	assert.True(t, expected.IsSame(denseRes), "AddSparse")
+
+	denseRes.MulSparse(dense, a)
+	expected.Mul(dense, a.ToDense())
+	assert.True(t, expected.IsSame(denseRes), "MulSparse")
+
+	res = FromVec(12, 0, -1, 0, 25).ToSparse()
+	res.Mod(gmp.NewInt(5))
+	assert.True(t, res.IsSame(FromVec(2, 0, 4).ToSparse()), "Mod")
+}
+
+func TestSparsePolynomial_EvalMod(t *testing.T) {
+	p := gmp.NewInt(0)
+	p.SetString("57896044618658097711785492504343953926634992332820282019728792006155588075521", 10)
+
+	sp := xnMinusOne(1 << 20)
+	sp.Add(sp, NewMonomial(-3, 12345))
+
+	x := gmp.NewInt(0)
+	x.Rand(randomness, p)
+
+	res := gmp.NewInt(0)
+	sp.EvalMod(x, p, res)
+
+	// x^(2^20) - 1 - 3 x^12345
+	expected, tmp := gmp.NewInt(0), gmp.NewInt(0)
+	expected.Exp(x, gmp.NewInt(1<<20), p)
+	expected.Sub(expected, gmp.NewInt(1))
+	tmp.Exp(x, gmp.NewInt(12345), p)
+	tmp.Mul(tmp, gmp.NewInt(3))
+	expected.Sub(expected, tmp)
+	expected.Mod(expected, p)
+	assert.Equal(t, 0, expected.Cmp(res), "EvalMod")
+
+	dense := FromVec(4, 0, 0, 9, 1)
+	expectedDense := gmp.NewInt(0)
+	dense.EvalMod(x, p, expectedDense)
+	dense.ToSparse().EvalMod(x, p, res)
+	assert.Equal(t, 0, expectedDense.Cmp(res), "same as Polynomial.EvalMod")
+}
+
+func TestDivModSparse(t *testing.T) {
+	p := gmp.NewInt(0)
+	p.SetString("57896044618658097711785492504343953926634992332820282019728792006155588075521", 10)
+
+	a, err := NewRand(100, randomness, p)
+	assert.Nil(t, err, "NewRand")
+
+	for _, b := range []SparsePolynomial{xnMinusOne(16), NewMonomial(3, 40), FromVec(-1, 0, 0, 0, 0, 0, 0, 7).ToSparse(), NewMonomial(2, 0), NewMonomial(1, 101)} {
+		q, r := NewEmpty(), NewEmpty()
+		assert.Nil(t, DivModSparse(a, b, p, &q, &r), "DivModSparse %s", b)
+
+		expectedQ, expectedR := NewEmpty(), NewEmpty()
+		assert.Nil(t, DivMod(a, b.ToDense(), p, &expectedQ, &expectedR), "DivMod")
+		assert.True(t, expectedQ.IsSame(q), "quotient by %s", b)
+		assert.True(t, expectedR.IsSame(r), "remainder by %s", b)
+		if b.GetDegree() > 0 {
+			assert.True(t, r.GetDegree() < b.GetDegree(), "remainder degree")
+		}
+	}
+
+	q, r := NewEmpty(), NewEmpty()
+	assert.NotNil(t, DivModSparse(a, SparsePolynomial{}, p, &q, &r), "divide by zero")
+	assert.NotNil(t, DivModSparse(a, NewMonomial(6, 2), gmp.NewInt(9), &q, &r), "leading coefficient not invertible")
+}
+
+func BenchmarkDivModSparse(b *testing.B) {
+	p := gmp.NewInt(0)
+	p.SetString("57896044618658097711785492504343953926634992332820282019728792006155588075521", 10)
+	a, _ := NewRand(2048, randomness, p)
+	sp := xnMinusOne(1024)
+	dense := sp.ToDense()
+
+	b.Run("sparse", func(b *testing.B) {
+		q, r := NewEmpty(), NewEmpty()
+		for i := 0; i < b.N; i++ {
+			DivModSparse(a, sp, p, &q, &r)
+		}
+	})
+
+	b.Run("dense", func(b *testing.B) {
+		q, r := NewEmpty(), NewEmpty()
+		for i := 0; i < b.N; i++ {
+			DivMod(a, dense, p, &q, &r)
+		}
+	})
+}
@@ -0,0 +1,186 @@
+package polyring
+
+import (
+	"errors"
+
+	"github.com/ncw/gmp"
+)
+
+// MultipointThreshold is the number of points from which EvalModArray and interpolation.LagrangeInterpolate
+// switch to a SubproductTree. See BenchmarkSubproductTree to tune it
+var MultipointThreshold = 256
+
+// subproductLeaf is the number of points below which a node evaluates with Horner's rule
+const subproductLeaf = 8
+
+// SubproductTree is the binary tree of the products of x - points[i] over ranges of points: the leaves hold
+// the factors and each node the product of its children's. It gives O(M(n) log n) multipoint evaluation
+// and interpolation, M being the cost of Field.Mul
+type SubproductTree struct {
+	field  *Field
+	points []*gmp.Int
+	root   *subproductNode
+}
+
+// subproductNode holds poly = (x - points[lo]) ... (x - points[hi-1])
+type subproductNode struct {
+	poly        Polynomial
+	lo, hi      int
+	left, right *subproductNode
+}
+
+// NewSubproductTree builds the subproduct tree of points
+func (f *Field) NewSubproductTree(points []*gmp.Int) (*SubproductTree, error) {
+	if len(points) == 0 {
+		return nil, errors.New("no points")
+	}
+
+	reduced := make([]*gmp.Int, len(points))
+	for i := range points {
+		reduced[i] = gmp.NewInt(0)
+		reduced[i].Mod(points[i], f.p)
+	}
+
+	tree := &SubproductTree{field: f, points: reduced}
+	tree.root = tree.build(0, len(points))
+
+	return tree, nil
+}
+
+// build returns the node of points[lo:hi]
+func (tree *SubproductTree) build(lo, hi int) *subproductNode {
+	node := &subproductNode{lo: lo, hi: hi}
+
+	if hi-lo == 1 {
+		node.poly = FromVec(0, 1)
+		node.poly.GetPtrToConstant().Neg(tree.points[lo])
+		tree.field.Reduce(&node.poly)
+		return node
+	}
+
+	mid := (lo + hi) / 2
+	node.left = tree.build(lo, mid)
+	node.right = tree.build(mid, hi)
+
+	node.poly = NewEmpty()
+	tree.field.Mul(&node.poly, node.left.poly, node.right.poly)
+
+	return node
+}
+
+// Vanishing returns the product of x - points[i] over all points
+func (tree *SubproductTree) Vanishing() Polynomial {
+	return tree.root.poly.DeepCopy()
+}
+
+// Evaluate returns poly(points[i]) mod p for all points, reducing poly modulo the nodes
+// from the root down to the leaves
+func (tree *SubproductTree) Evaluate(poly Polynomial) []*gmp.Int {
+	results := make([]*gmp.Int, len(tree.points))
+	VecInit(results)
+
+	tree.evaluate(tree.root, poly, results)
+
+	return results
+}
+
+func (tree *SubproductTree) evaluate(node *subproductNode, poly Polynomial, results []*gmp.Int) {
+	rem := poly
+	if poly.GetDegree() >= node.poly.GetDegree() {
+		quot := NewEmpty()
+		rem = NewEmpty()
+		if err := tree.field.DivMod(poly, node.poly, &quot, &rem); err != nil {
+			panic(err.Error())
+		}
+	}
+
+	if node.hi-node.lo <= subproductLeaf {
+		for i := node.lo; i < node.hi; i++ {
+			tree.field.Eval(results[i], rem, tree.points[i])
+		}
+		return
+	}
+
+	tree.evaluate(node.left, rem, results)
+	tree.evaluate(node.right, rem, results)
+}
+
+// Interpolate returns the polynomial of degree less than the number of points through (points[i], ys[i]).
+// With m the product of x - points[i], it is the sum of ys[i] / m'(points[i]) * m(x) / (x - points[i]),
+// which is combined from the leaves up. The points must be distinct
+func (tree *SubproductTree) Interpolate(ys []*gmp.Int) (Polynomial, error) {
+	if len(ys) != len(tree.points) {
+		return Polynomial{}, errors.New("mismatch length")
+	}
+
+	derivative := NewEmpty()
+	tree.field.Derivative(&derivative, tree.root.poly)
+
+	weights := tree.Evaluate(derivative)
+	for i := range weights {
+		if err := tree.field.Inverse(weights[i], weights[i]); err != nil {
+			return Polynomial{}, errors.New("duplicate points")
+		}
+		weights[i].Mul(weights[i], ys[i])
+		weights[i].Mod(weights[i], tree.field.p)
+	}
+
+	return tree.combine(tree.root, weights), nil
+}
+
+// combine returns the sum of weights[i] * node.poly / (x - points[i]) over the points of node
+func (tree *SubproductTree) combine(node *subproductNode, weights []*gmp.Int) Polynomial {
+	if node.left == nil {
+		res := NewEmpty()
+		res.GetPtrToConstant().Set(weights[node.lo])
+		return res
+	}
+
+	left := tree.combine(node.left, weights)
+	right := tree.combine(node.right, weights)
+
+	// left * right.poly + right * left.poly
+	res, tmp := NewEmpty(), NewEmpty()
+	tree.field.Mul(&res, left, node.right.poly)
+	tree.field.Mul(&tmp, right, node.left.poly)
+	tree.field.Add(&res, res, tmp)
+
+	return res
+}
+
+// Derivative sets res to the formal derivative of poly mod p
+func (f *Field) Derivative(res *Polynomial, poly Polynomial) {
+	deg := poly.GetDegree()
+	if deg == 0 {
+		res.ResetTo(NewEmpty())
+		return
+	}
+
+	der, err := New(deg - 1)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	for i := 1; i <= deg; i++ {
+		der.coeff[i-1].Mul(poly.coeff[i], gmp.NewInt(int64(i)))
+	}
+
+	f.Reduce(&der)
+	res.ResetTo(der)
+}
+
+// EvalMultipoint sets results[i] to poly(x[i]) mod p with a SubproductTree
+func (f *Field) EvalMultipoint(poly Polynomial, x []*gmp.Int, results []*gmp.Int) {
+	if len(x) == 0 {
+		return
+	}
+
+	tree, err := f.NewSubproductTree(x)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	for i, y := range tree.Evaluate(poly) {
+		results[i].Set(y)
+	}
+}
@@ -0,0 +1,126 @@
+package polyring
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubproductTree(t *testing.T) {
+	f, err := NewField(ScalarField)
+	assert.Nil(t, err, "NewField")
+
+	for _, n := range []int{1, 2, 7, 9, 33, 100} {
+		points := make([]*gmp.Int, n)
+		VecInit(points)
+		VecRand(points, ScalarField, randomness)
+
+		tree, err := f.NewSubproductTree(points)
+		assert.Nil(t, err, "NewSubproductTree")
+		assert.True(t, tree.Vanishing().IsSame(f.FromRoots(points)), "Vanishing")
+
+		// a polynomial of higher degree than the number of points
+		poly, _ := f.Rand(n+5, randomness)
+		values := tree.Evaluate(poly)
+
+		expected := gmp.NewInt(0)
+		for i := range points {
+			poly.EvalMod(points[i], ScalarField, expected)
+			assert.Zero(t, values[i].Cmp(expected), "Evaluate %d of %d", i, n)
+		}
+
+		// interpolating the evaluations of a polynomial of degree n-1 returns it
+		poly, _ = f.Rand(n-1, randomness)
+		inter, err := tree.Interpolate(tree.Evaluate(poly))
+		assert.Nil(t, err, "Interpolate")
+		assert.True(t, inter.IsSame(poly), "Interpolate %d", n)
+	}
+
+	_, err = f.NewSubproductTree(nil)
+	assert.NotNil(t, err, "no points")
+
+	tree, _ := f.NewSubproductTree([]*gmp.Int{gmp.NewInt(1), gmp.NewInt(2), gmp.NewInt(1)})
+	_, err = tree.Interpolate([]*gmp.Int{gmp.NewInt(1), gmp.NewInt(2), gmp.NewInt(3)})
+	assert.NotNil(t, err, "duplicate points")
+	_, err = tree.Interpolate([]*gmp.Int{gmp.NewInt(1)})
+	assert.NotNil(t, err, "mismatch length")
+}
+
+func TestField_Derivative(t *testing.T) {
+	f, _ := NewField(gmp.NewInt(7))
+
+	res := NewEmpty()
+	f.Derivative(&res, FromVec(5, 3, 4, 2))
+	assert.True(t, res.IsSame(FromVec(3, 1, 6)), "Derivative")
+
+	// the coefficient of x^7 vanishes mod 7
+	f.Derivative(&res, FromVec(0, 1, 0, 0, 0, 0, 0, 1))
+	assert.True(t, res.IsSame(NewOne()), "Derivative mod 7")
+
+	f.Derivative(&res, FromVec(5))
+	assert.True(t, res.IsZero(), "Derivative of a constant")
+}
+
+func TestPolynomial_EvalModArray_Multipoint(t *testing.T) {
+	defer func(threshold int) { MultipointThreshold = threshold }(MultipointThreshold)
+	MultipointThreshold = 16
+
+	n := 2 * MultipointThreshold
+
+	x := make([]*gmp.Int, n)
+	VecInit(x)
+	VecRand(x, ScalarField, randomness)
+
+	poly, _ := NewRand(n, randomness, ScalarField)
+
+	// above the threshold
+	results := make([]*gmp.Int, n)
+	VecInit(results)
+	poly.EvalModArray(x, ScalarField, results)
+
+	expected := gmp.NewInt(0)
+	for i := range x {
+		poly.EvalMod(x[i], ScalarField, expected)
+		assert.Zero(t, results[i].Cmp(expected), "EvalModArray %d", i)
+	}
+
+	// a composite modulus is evaluated point by point
+	mod := gmp.NewInt(1000)
+	poly.EvalModArray(x, mod, results)
+	for i := range x {
+		poly.EvalMod(x[i], mod, expected)
+		assert.Zero(t, results[i].Cmp(expected), "EvalModArray mod 1000 %d", i)
+	}
+}
+
+// BenchmarkSubproductTree compares evaluating a polynomial of degree n at n points with Horner's rule
+// and with a subproduct tree
+func BenchmarkSubproductTree(b *testing.B) {
+	f, _ := NewField(ScalarField)
+
+	for _, n := range []int{64, 256, 1024} {
+		x := make([]*gmp.Int, n)
+		VecInit(x)
+		VecRand(x, ScalarField, randomness)
+		results := make([]*gmp.Int, n)
+		VecInit(results)
+
+		poly, _ := f.Rand(n-1, randomness)
+
+		b.Run(fmt.Sprintf("Horner/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for j := range x {
+					poly.EvalMod(x[j], ScalarField, results[j])
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("Tree/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				f.EvalMultipoint(poly, x, results)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package recovery
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/conv"
+	"github.com/nikamn/BC-SSE/utils/interpolation"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+)
+
+// Share recovery for a node that lost its share f(target):
+//  1. each of the degree+1 helpers samples a mask delta_j with delta_j(target) = 0 (NewMask)
+//     and sends delta_j(x_i) to every helper i (MaskShares)
+//  2. helper i sends f(x_i) + sum_j delta_j(x_i) to the recovering node (Contribution)
+//  3. the recovering node interpolates f + sum_j delta_j at target (Recover)
+// f + sum_j delta_j is a random polynomial that agrees with f only at target, so the recovering
+// node learns nothing but its own share, and helpers only ever see masked values.
+
+// NewMask returns a random polynomial delta of the given degree with delta(target) = 0.
+// rnd defaults to crypto/rand.Reader if nil
+func NewMask(degree int, target *gmp.Int, rnd io.Reader, p *gmp.Int) (polyring.Polynomial, error) {
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+
+	if degree < 0 {
+		return polyring.Polynomial{}, errors.New("degree must be non-negative")
+	}
+
+	// a degree 0 polynomial vanishing at target is zero
+	if degree == 0 {
+		return polyring.NewEmpty(), nil
+	}
+
+	// delta(x) = (x - target) * g(x) for a random g of degree - 1
+	g, err := polyring.New(degree - 1)
+	if err != nil {
+		return polyring.Polynomial{}, err
+	}
+
+	order := conv.GmpInt2BigInt(p)
+	for i := 0; i < degree; i++ {
+		ci, err := rand.Int(rnd, order)
+		for err == nil && i == degree-1 && ci.Sign() == 0 {
+			ci, err = rand.Int(rnd, order)
+		}
+		if err != nil {
+			return polyring.Polynomial{}, err
+		}
+
+		g.SetCoefficientBig(i, conv.BigInt2GmpInt(ci))
+	}
+
+	root, err := polyring.New(1)
+	if err != nil {
+		return polyring.Polynomial{}, err
+	}
+	root.SetCoefficient(1, 1)
+	root.GetPtrToConstant().Neg(target)
+
+	delta := polyring.NewEmpty()
+	delta.Mul(g, root)
+	delta.Mod(p)
+
+	return delta, nil
+}
+
+// MaskShares returns delta(x[0]), ..., delta(x[n-1]), one masking value for each helper
+func MaskShares(delta polyring.Polynomial, x []*gmp.Int, p *gmp.Int) []*gmp.Int {
+	masks := make([]*gmp.Int, len(x))
+	polyring.VecInit(masks)
+	delta.EvalModArray(x, p, masks)
+
+	return masks
+}
+
+// Contribution sets res to share + sum(masks) mod p, where masks are the values received from all helpers
+func Contribution(res *gmp.Int, share *gmp.Int, masks []*gmp.Int, p *gmp.Int) {
+	res.Set(share)
+	for _, m := range masks {
+		res.Add(res, m)
+	}
+	res.Mod(res, p)
+}
+
+// Recover sets res to the share at target, given degree+1 contributions from helpers at x
+func Recover(res *gmp.Int, degree int, x []*gmp.Int, contributions []*gmp.Int, target *gmp.Int, p *gmp.Int) error {
+	if len(x) != len(contributions) {
+		return errors.New("mismatch length")
+	}
+
+	if len(x) < degree+1 {
+		return errors.New("not enough contributions")
+	}
+
+	for _, xi := range x {
+		if xi.Cmp(target) == 0 {
+			return errors.New("target must not be a helper")
+		}
+	}
+
+	masked, err := interpolation.LagrangeInterpolate(degree, x, contributions, p)
+	if err != nil {
+		return err
+	}
+
+	masked.EvalMod(target, p, res)
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package recovery
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+	"github.com/stretchr/testify/assert"
+)
+
+const RandSeed = 3
+
+func TestNewMask(t *testing.T) {
+	p := gmp.NewInt(15486511)
+	rnd := rand.New(rand.NewSource(RandSeed))
+	target := gmp.NewInt(4)
+
+	delta, err := NewMask(6, target, rnd, p)
+	assert.Nil(t, err, "NewMask")
+	assert.Equal(t, 6, delta.GetDegree())
+
+	res := gmp.NewInt(1)
+	delta.EvalMod(target, p, res)
+	assert.Zero(t, res.CmpInt32(0), "delta(target)")
+
+	zero, err := NewMask(0, target, rnd, p)
+	assert.Nil(t, err, "NewMask")
+	assert.True(t, zero.IsZero())
+
+	delta, err = NewMask(6, target, nil, p)
+	assert.Nil(t, err, "NewMask from crypto/rand")
+	assert.Equal(t, 6, delta.GetDegree())
+	delta.EvalMod(target, p, res)
+	assert.Zero(t, res.CmpInt32(0), "delta(target)")
+}
+
+func TestRecover(t *testing.T) {
+	const degree = 4
+	const n = 10
+	p := gmp.NewInt(15486511)
+	rnd := rand.New(rand.NewSource(RandSeed))
+
+	poly, err := polyring.NewRand(degree, rnd, p)
+	assert.Nil(t, err, "NewRand")
+
+	// node 7 lost its share, nodes 1, 2, 3, 5, 9 help
+	target := gmp.NewInt(7)
+	helpers := []int64{1, 2, 3, 5, 9}
+
+	x := make([]*gmp.Int, len(helpers))
+	shares := make([]*gmp.Int, len(helpers))
+	polyring.VecInit(shares)
+	for i, h := range helpers {
+		x[i] = gmp.NewInt(h)
+	}
+	poly.EvalModArray(x, p, shares)
+
+	// received[i][j] is the mask helper j sent to helper i
+	received := make([][]*gmp.Int, len(helpers))
+	for i := range received {
+		received[i] = make([]*gmp.Int, len(helpers))
+	}
+	for j := range helpers {
+		delta, err := NewMask(degree, target, rnd, p)
+		assert.Nil(t, err, "NewMask")
+
+		for i, m := range MaskShares(delta, x, p) {
+			received[i][j] = m
+		}
+	}
+
+	contributions := make([]*gmp.Int, len(helpers))
+	polyring.VecInit(contributions)
+	for i := range helpers {
+		Contribution(contributions[i], shares[i], received[i], p)
+		assert.NotZero(t, contributions[i].Cmp(shares[i]), "contribution is blinded")
+	}
+
+	recovered := gmp.NewInt(0)
+	err = Recover(recovered, degree, x, contributions, target, p)
+	assert.Nil(t, err, "Recover")
+
+	expected := gmp.NewInt(0)
+	poly.EvalMod(target, p, expected)
+	assert.Zero(t, expected.Cmp(recovered), "recovered share")
+
+	// the masked polynomial must not leak any other share, e.g. the secret at 0
+	leaked := gmp.NewInt(0)
+	err = Recover(leaked, degree, x, contributions, gmp.NewInt(0), p)
+	assert.Nil(t, err, "Recover")
+	assert.NotZero(t, leaked.Cmp(poly.GetPtrToConstant()), "secret is masked")
+
+	err = Recover(recovered, degree, x[:degree], contributions[:degree], target, p)
+	assert.NotNil(t, err, "not enough contributions")
+
+	err = Recover(recovered, degree, x, contributions, x[0], p)
+	assert.NotNil(t, err, "target is a helper")
+}
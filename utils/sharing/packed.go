@@ -0,0 +1,142 @@
+package sharing
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/interpolation"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+)
+
+// Packed (Franklin-Yung) secret sharing puts k secrets into one polynomial f of degree t+k-1,
+// with f(-j) = secrets[j-1] for j = 1, ..., k. Any t shares reveal nothing about the secrets,
+// and any t+k shares reconstruct all of them.
+
+// SecretPoints returns the k points -1, ..., -k (mod p) that hold the secrets
+func SecretPoints(k int, p *gmp.Int) []*gmp.Int {
+	points := make([]*gmp.Int, k)
+	for j := range points {
+		points[j] = gmp.NewInt(int64(-(j + 1)))
+		points[j].Mod(points[j], p)
+	}
+
+	return points
+}
+
+// PackedSplit returns the shares f(x[0]), ..., f(x[n-1]) of the secrets with privacy threshold t
+func PackedSplit(secrets []*gmp.Int, t int, x []*gmp.Int, p *gmp.Int, rnd *rand.Rand) ([]*gmp.Int, error) {
+	if t < 0 {
+		return nil, fmt.Errorf("threshold must be non-negative, got %d", t)
+	}
+
+	if len(x) < t+len(secrets) {
+		return nil, fmt.Errorf("need at least %d parties, got %d", t+len(secrets), len(x))
+	}
+
+	if err := checkPoints(x, len(secrets), p); err != nil {
+		return nil, err
+	}
+
+	r := make([]*gmp.Int, t)
+	polyring.VecInit(r)
+	polyring.VecRand(r, p, rnd)
+
+	poly, err := packedPolynomial(secrets, r, p)
+	if err != nil {
+		return nil, err
+	}
+
+	shares := make([]*gmp.Int, len(x))
+	polyring.VecInit(shares)
+	poly.EvalModArray(x, p, shares)
+
+	return shares, nil
+}
+
+// PackedCombine returns the k secrets from at least t+k shares y at x
+func PackedCombine(k, t int, x []*gmp.Int, y []*gmp.Int, p *gmp.Int) ([]*gmp.Int, error) {
+	if len(x) != len(y) {
+		return nil, errors.New("mismatch length")
+	}
+
+	if len(x) < t+k {
+		return nil, fmt.Errorf("need at least %d shares, got %d", t+k, len(x))
+	}
+
+	if err := checkPoints(x, k, p); err != nil {
+		return nil, err
+	}
+
+	poly, err := interpolation.LagrangeInterpolate(t+k-1, x, y, p)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := make([]*gmp.Int, k)
+	polyring.VecInit(secrets)
+	poly.EvalModArray(SecretPoints(k, p), p, secrets)
+
+	return secrets, nil
+}
+
+// packedPolynomial returns f = L + Z * r, where L interpolates the secrets at the secret points,
+// Z vanishes on all secret points and r has the t coefficients given.
+// f is uniform among the polynomials of degree t+k-1 that hold the secrets when r is uniform
+func packedPolynomial(secrets []*gmp.Int, r []*gmp.Int, p *gmp.Int) (polyring.Polynomial, error) {
+	k := len(secrets)
+	if k == 0 {
+		return polyring.Polynomial{}, errors.New("no secrets")
+	}
+
+	points := SecretPoints(k, p)
+
+	poly, err := interpolation.LagrangeInterpolate(k-1, points, secrets, p)
+	if err != nil {
+		return polyring.Polynomial{}, err
+	}
+
+	if len(r) == 0 {
+		return poly, nil
+	}
+
+	// Z(x) = (x+1)(x+2)...(x+k)
+	zero := polyring.NewOne()
+	root := polyring.FromVec(0, 1)
+	for _, point := range points {
+		root.GetPtrToConstant().Neg(point)
+		zero.MulSelf(root)
+		zero.Mod(p)
+	}
+
+	random, err := polyring.New(len(r) - 1)
+	if err != nil {
+		return polyring.Polynomial{}, err
+	}
+	for i := range r {
+		random.SetCoefficientBig(i, r[i])
+	}
+
+	masked := polyring.NewEmpty()
+	masked.Mul(zero, random)
+	poly.AddSelf(masked)
+	poly.Mod(p)
+
+	return poly, nil
+}
+
+// checkPoints makes sure no share is placed at a secret point
+func checkPoints(x []*gmp.Int, k int, p *gmp.Int) error {
+	tmp := gmp.NewInt(0)
+	for _, xi := range x {
+		tmp.Mod(xi, p)
+		for _, point := range SecretPoints(k, p) {
+			if tmp.Cmp(point) == 0 {
+				return fmt.Errorf("share point %s holds a secret", xi.String())
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package sharing
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/nikamn/BC-SSE/utils/polyring"
+	"github.com/stretchr/testify/assert"
+)
+
+const RandSeed = 7
+
+func points(from, to int64) []*gmp.Int {
+	x := make([]*gmp.Int, 0, to-from+1)
+	for i := from; i <= to; i++ {
+		x = append(x, gmp.NewInt(i))
+	}
+	return x
+}
+
+func TestPackedCombine(t *testing.T) {
+	const k = 4
+	const threshold = 3
+	p := gmp.NewInt(15486511)
+	rnd := rand.New(rand.NewSource(RandSeed))
+
+	secrets := make([]*gmp.Int, k)
+	polyring.VecInit(secrets)
+	polyring.VecRand(secrets, p, rnd)
+
+	x := points(1, 10)
+	shares, err := PackedSplit(secrets, threshold, x, p, rnd)
+	assert.Nil(t, err, "PackedSplit")
+
+	// any t+k shares reconstruct all secrets
+	for _, from := range []int{0, 1, 3} {
+		combined, err := PackedCombine(k, threshold, x[from:from+threshold+k], shares[from:from+threshold+k], p)
+		assert.Nil(t, err, "PackedCombine")
+		for j := range secrets {
+			assert.Zero(t, secrets[j].Cmp(combined[j]), "secret %d", j)
+		}
+	}
+
+	// t+k-1 shares are not enough
+	_, err = PackedCombine(k, threshold, x[:threshold+k-1], shares[:threshold+k-1], p)
+	assert.NotNil(t, err, "below reconstruction threshold")
+}
+
+func TestPackedSplit_Privacy(t *testing.T) {
+	const threshold = 2
+	p := gmp.NewInt(11)
+
+	// any t shares take every value exactly once over all randomness, whatever the secrets are
+	x := []*gmp.Int{gmp.NewInt(1), gmp.NewInt(4)}
+	for _, secrets := range [][]*gmp.Int{
+		{gmp.NewInt(3), gmp.NewInt(5)},
+		{gmp.NewInt(7), gmp.NewInt(2)},
+		{gmp.NewInt(0), gmp.NewInt(0)},
+	} {
+		seen := make(map[[2]int64]int)
+		for r0 := int64(0); r0 < 11; r0++ {
+			for r1 := int64(0); r1 < 11; r1++ {
+				poly, err := packedPolynomial(secrets, []*gmp.Int{gmp.NewInt(r0), gmp.NewInt(r1)}, p)
+				assert.Nil(t, err, "packedPolynomial")
+				assert.True(t, poly.GetDegree() <= threshold+len(secrets)-1, "degree")
+
+				y := make([]*gmp.Int, len(x))
+				polyring.VecInit(y)
+				poly.EvalModArray(x, p, y)
+				seen[[2]int64{y[0].Int64(), y[1].Int64()}]++
+			}
+		}
+
+		assert.Equal(t, 121, len(seen), "uniform shares")
+	}
+}
+
+func TestPackedSplit_Invalid(t *testing.T) {
+	p := gmp.NewInt(11)
+	rnd := rand.New(rand.NewSource(RandSeed))
+	secrets := []*gmp.Int{gmp.NewInt(1), gmp.NewInt(2)}
+
+	_, err := PackedSplit(secrets, 2, points(1, 3), p, rnd)
+	assert.NotNil(t, err, "not enough parties")
+
+	// 10 = -1 mod 11 holds the first secret
+	_, err = PackedSplit(secrets, 2, points(7, 10), p, rnd)
+	assert.NotNil(t, err, "share at a secret point")
+
+	_, err = PackedSplit(nil, 2, points(1, 4), p, rnd)
+	assert.NotNil(t, err, "no secrets")
+}